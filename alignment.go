@@ -0,0 +1,76 @@
+package log
+
+import (
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Alignment defines how to align the columns of log lines.
+type Alignment struct {
+	// LevelWidth is the minimum width to pad level labels to.
+	// Level labels are always padded to at least the width of
+	// the longest level label of the theme.
+	LevelWidth int
+	// ComponentWidth is the minimum width to pad component
+	// names to, excluding the enclosing brackets.
+	ComponentWidth int
+	// LearnComponentWidth grows the component width to the
+	// width of the longest component logged so far by the
+	// logger and its children.
+	LearnComponentWidth bool
+	// RightAlignCaller right aligns the caller to the width
+	// of the terminal for writers writing to a terminal.
+	// For other writers, the caller is written after a tab.
+	RightAlignCaller bool
+}
+
+// aligner is shared between a logger and its children,
+// such that learned widths are common to all of them.
+type aligner struct {
+	settings Alignment
+	// learnedComponentWidth is accessed atomically.
+	learnedComponentWidth int64
+}
+
+func newAligner(settings Alignment) *aligner {
+	return &aligner{
+		settings: settings,
+	}
+}
+
+func (a *aligner) levelWidth(theme *Theme) (width int) {
+	width = a.settings.LevelWidth
+	for _, level := range allLevels {
+		labelWidth := utf8.RuneCountInString(theme.levelStyle(level).Label)
+		if labelWidth > width {
+			width = labelWidth
+		}
+	}
+	return width
+}
+
+// componentWidth returns the width to pad the component to,
+// learning from the component given if enabled.
+func (a *aligner) componentWidth(component string) (width int) {
+	width = a.settings.ComponentWidth
+	if !a.settings.LearnComponentWidth {
+		return width
+	}
+
+	componentWidth := int64(utf8.RuneCountInString(component))
+	for {
+		learned := atomic.LoadInt64(&a.learnedComponentWidth)
+		if componentWidth <= learned {
+			componentWidth = learned
+			break
+		}
+		if atomic.CompareAndSwapInt64(&a.learnedComponentWidth, learned, componentWidth) {
+			break
+		}
+	}
+
+	if int(componentWidth) > width {
+		width = int(componentWidth)
+	}
+	return width
+}
@@ -0,0 +1,154 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_aligner_levelWidth(t *testing.T) {
+	t.Parallel()
+
+	theme := DefaultTheme()
+
+	aligner := newAligner(Alignment{})
+	assert.Equal(t, 5, aligner.levelWidth(&theme))
+
+	aligner = newAligner(Alignment{LevelWidth: 7})
+	assert.Equal(t, 7, aligner.levelWidth(&theme))
+}
+
+func Test_aligner_componentWidth(t *testing.T) {
+	t.Parallel()
+
+	aligner := newAligner(Alignment{ComponentWidth: 3})
+	assert.Equal(t, 3, aligner.componentWidth("database"))
+
+	aligner = newAligner(Alignment{ComponentWidth: 3, LearnComponentWidth: true})
+	assert.Equal(t, 3, aligner.componentWidth("db"))
+	assert.Equal(t, 8, aligner.componentWidth("database"))
+	assert.Equal(t, 8, aligner.componentWidth("db"))
+}
+
+func Test_formattedLine(t *testing.T) {
+	t.Parallel()
+
+	red := color.New(color.FgRed)
+	red.EnableColor()
+
+	testCases := map[string]struct {
+		formatted     formattedLine
+		terminalWidth int
+		line          string
+	}{
+		"no caller": {
+			formatted: formattedLine{
+				text:             "INFO message",
+				lastLineWidth:    12,
+				rightAlignCaller: true,
+			},
+			terminalWidth: 30,
+			line:          "INFO message\n",
+		},
+		"right align disabled": {
+			formatted: formattedLine{
+				text:          "INFO message",
+				caller:        "main.go:L1",
+				lastLineWidth: 12,
+				callerWidth:   10,
+			},
+			terminalWidth: 30,
+			line:          "INFO message\tmain.go:L1\n",
+		},
+		"not a terminal": {
+			formatted: formattedLine{
+				text:             "INFO message",
+				caller:           "main.go:L1",
+				lastLineWidth:    12,
+				callerWidth:      10,
+				rightAlignCaller: true,
+			},
+			line: "INFO message\tmain.go:L1\n",
+		},
+		"terminal too narrow": {
+			formatted: formattedLine{
+				text:             "INFO message",
+				caller:           "main.go:L1",
+				lastLineWidth:    12,
+				callerWidth:      10,
+				rightAlignCaller: true,
+			},
+			terminalWidth: 22,
+			line:          "INFO message\tmain.go:L1\n",
+		},
+		"right aligned": {
+			formatted: formattedLine{
+				text:             "INFO message",
+				caller:           "main.go:L1",
+				lastLineWidth:    12,
+				callerWidth:      10,
+				rightAlignCaller: true,
+			},
+			terminalWidth: 30,
+			line:          "INFO message        main.go:L1\n",
+		},
+		"right aligned colored line": {
+			formatted: formattedLine{
+				text:             "INFO message",
+				caller:           "main.go:L1",
+				lineColor:        red,
+				lastLineWidth:    12,
+				callerWidth:      10,
+				rightAlignCaller: true,
+			},
+			terminalWidth: 24,
+			line:          red.Sprint("INFO message  main.go:L1") + "\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			line := testCase.formatted.forTerminal(testCase.terminalWidth)
+
+			assert.Equal(t, testCase.line, line)
+		})
+	}
+}
+
+func Test_printedWidth(t *testing.T) {
+	t.Parallel()
+
+	red := color.New(color.FgRed, color.Bold)
+	red.EnableColor()
+
+	assert.Equal(t, 0, printedWidth(""))
+	assert.Equal(t, 5, printedWidth("héllo"))
+	assert.Equal(t, 9, printedWidth(red.Sprint("ERROR")+" abc"))
+}
+
+func Test_Logger_alignment(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+	parent := New(SetWriters(buffer), SetTimeFormat(""), SetCallerFile(true),
+		SetAlignment(Alignment{
+			LearnComponentWidth: true,
+			RightAlignCaller:    true,
+		}))
+	parent.Info("no component")
+	database := parent.New(SetComponent("database"))
+	database.Warn("query")
+	parent.New(SetComponent("db")).Info("ping")
+	parent.Error("no component")
+
+	const expected = "INFO  no component\talignment_test.go\n" +
+		"WARN  [database] query\talignment_test.go\n" +
+		"INFO  [db]       ping\talignment_test.go\n" +
+		"ERROR            no component\talignment_test.go\n"
+	assert.Equal(t, expected, buffer.String())
+}
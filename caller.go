@@ -0,0 +1,47 @@
+package log
+
+import "github.com/qdm12/log/internal/caller"
+
+// CallerPathMode is the mode to display the caller file path.
+type CallerPathMode uint8
+
+const (
+	// CallerPathBase displays the caller file base name,
+	// such as handler.go.
+	CallerPathBase = CallerPathMode(caller.PathBase)
+	// CallerPathPackage displays the caller file base name
+	// prefixed with its package directory, such as server/handler.go.
+	CallerPathPackage = CallerPathMode(caller.PathPackage)
+	// CallerPathModule displays the caller file path relative to
+	// its module root, such as internal/server/handler.go.
+	// It falls back on the path relative to GOPATH/src or GOROOT/src,
+	// and then on the package relative path.
+	CallerPathModule = CallerPathMode(caller.PathModule)
+	// CallerPathFull displays the full caller file path.
+	CallerPathFull = CallerPathMode(caller.PathFull)
+)
+
+// CallerFuncMode is the mode to display the caller function name.
+type CallerFuncMode uint8
+
+const (
+	// CallerFuncShort displays the caller function name after its
+	// last dot, such as ServeHTTP.
+	CallerFuncShort = CallerFuncMode(caller.FuncShort)
+	// CallerFuncPackage displays the package qualified caller
+	// function name, such as server.(*Handler).ServeHTTP.
+	CallerFuncPackage = CallerFuncMode(caller.FuncPackage)
+	// CallerFuncFull displays the fully qualified caller function
+	// name, such as github.com/user/repo/server.(*Handler).ServeHTTP.
+	CallerFuncFull = CallerFuncMode(caller.FuncFull)
+)
+
+// Helper marks the calling function as a logging helper function,
+// similarly to testing.T.Helper. When determining the caller of
+// a log call, helper functions frames are skipped, so the caller
+// shown is the function calling the helper function.
+// Helper can be called from multiple goroutines simultaneously
+// and marks the function for all loggers.
+func Helper() {
+	caller.MarkHelper(1)
+}
@@ -0,0 +1,69 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func currentLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func testLogAndReturn(logger *Logger, err error) error {
+	Helper()
+	logger.Error(err.Error())
+	return err
+}
+
+func testWrapLogAndReturn(logger *Logger, err error) error {
+	Helper()
+	return testLogAndReturn(logger, fmt.Errorf("wrapped: %w", err))
+}
+
+func testLogWithoutHelper(logger *Logger, message string) {
+	logger.Info(message)
+}
+
+func Test_Logger_callerSkip(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	t.Run("helpers", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := bytes.NewBuffer(nil)
+		logger := New(SetWriters(buffer), SetTimeFormat(""),
+			SetCallerFile(true), SetCallerLine(true))
+
+		_, line := testLogAndReturn(logger, errTest), currentLine()
+		assert.Equal(t, fmt.Sprintf("ERROR test error\tcaller_test.go:L%d\n", line), buffer.String())
+		buffer.Reset()
+
+		_, line = testWrapLogAndReturn(logger, errTest), currentLine()
+		assert.Equal(t, fmt.Sprintf("ERROR wrapped: test error\tcaller_test.go:L%d\n", line), buffer.String())
+	})
+
+	t.Run("skip inherited by children", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := bytes.NewBuffer(nil)
+		parent := New(SetWriters(buffer), SetTimeFormat(""),
+			SetCallerFunc(true), AddCallerSkip(1))
+		child := parent.New()
+
+		testLogWithoutHelper(child, "message")
+		assert.Equal(t, "INFO message\tfunc2\n", buffer.String())
+		buffer.Reset()
+
+		wrapper := func() { testLogWithoutHelper(child.New(AddCallerSkip(1)), "message") }
+		wrapper()
+		assert.Equal(t, "INFO message\tfunc2\n", buffer.String())
+	})
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qdm12/log"
+)
+
+// entry is a log entry parsed from a JSON log line.
+type entry struct {
+	level     log.Level
+	time      time.Time
+	component string
+	message   string
+	caller    log.Caller
+	fields    []log.Field
+}
+
+//nolint:gochecknoglobals
+var (
+	levelKeys     = []string{"level", "lvl", "severity"}
+	timeKeys      = []string{"time", "ts", "timestamp", "@timestamp"}
+	messageKeys   = []string{"msg", "message"}
+	componentKeys = []string{"component", "logger"}
+	callerKeys    = []string{"caller", "source"}
+	fileKeys      = []string{"file"}
+	lineKeys      = []string{"line"}
+	functionKeys  = []string{"func", "function"}
+)
+
+// parseEntry parses a JSON object log line into an entry.
+// Well known keys used by common Go JSON loggers are used for
+// the level, time, message, component and caller, and all other
+// keys are kept as fields sorted by key. It returns false if the
+// line is not a JSON object.
+func parseEntry(line []byte) (e entry, ok bool) {
+	var object map[string]json.RawMessage
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	err := decoder.Decode(&object)
+	if err != nil || object == nil {
+		return e, false
+	}
+
+	e.level = parseLevel(popRaw(object, levelKeys))
+	e.time = parseTime(popRaw(object, timeKeys))
+	e.message = rawToString(popRaw(object, messageKeys))
+	e.component = rawToString(popRaw(object, componentKeys))
+	e.caller = parseCaller(popRaw(object, callerKeys))
+	if file := popRaw(object, fileKeys); file != nil {
+		e.caller.File = rawToString(file)
+	}
+	if line := popRaw(object, lineKeys); line != nil {
+		e.caller.Line, _ = strconv.Atoi(rawToString(line))
+	}
+	if function := popRaw(object, functionKeys); function != nil {
+		e.caller.Function = rawToString(function)
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		e.fields = append(e.fields, log.Field{
+			Key:   key,
+			Value: rawToString(object[key]),
+		})
+	}
+
+	return e, true
+}
+
+// popRaw removes and returns the value of the first key found
+// in the object, or nil if none of the keys is present.
+func popRaw(object map[string]json.RawMessage, keys []string) (raw json.RawMessage) {
+	for _, key := range keys {
+		raw, ok := object[key]
+		if ok {
+			delete(object, key)
+			return raw
+		}
+	}
+	return nil
+}
+
+// rawToString returns the string value of a JSON string,
+// or the compacted JSON text for any other JSON value.
+func rawToString(raw json.RawMessage) string {
+	if raw == nil {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	buffer := bytes.NewBuffer(nil)
+	if err := json.Compact(buffer, raw); err != nil {
+		return string(raw)
+	}
+	return buffer.String()
+}
+
+// parseLevel parses a level name or a numeric level as used by
+// pino and bunyan. Levels without an equivalent are mapped to
+// the closest level, and unknown levels default to info.
+func parseLevel(raw json.RawMessage) log.Level {
+	s := rawToString(raw)
+	if number, err := strconv.Atoi(s); err == nil {
+		const debugMax, infoMax, warnMax = 20, 30, 40
+		switch {
+		case number <= debugMax:
+			return log.LevelDebug
+		case number <= infoMax:
+			return log.LevelInfo
+		case number <= warnMax:
+			return log.LevelWarn
+		default:
+			return log.LevelError
+		}
+	}
+
+	switch strings.ToLower(s) {
+	case "trace", "debug", "dbg":
+		return log.LevelDebug
+	case "warn", "warning", "wrn":
+		return log.LevelWarn
+	case "error", "err", "fatal", "panic", "dpanic",
+		"critical", "crit", "alert", "emerg", "emergency":
+		return log.LevelError
+	default:
+		return log.LevelInfo
+	}
+}
+
+// parseTime parses a time string or a Unix epoch number. The
+// epoch unit is deduced from its magnitude, such that seconds,
+// milliseconds, microseconds and nanoseconds are supported.
+// It returns the zero time if the value cannot be parsed.
+func parseTime(raw json.RawMessage) (t time.Time) {
+	s := rawToString(raw)
+	if s == "" {
+		return t
+	}
+
+	if epoch, err := strconv.ParseFloat(s, 64); err == nil {
+		const milliThreshold, microThreshold, nanoThreshold = 1e11, 1e14, 1e17
+		switch magnitude := math.Abs(epoch); {
+		case magnitude >= nanoThreshold:
+			return time.Unix(0, int64(epoch))
+		case magnitude >= microThreshold:
+			return time.UnixMicro(int64(epoch))
+		case magnitude >= milliThreshold:
+			return time.UnixMilli(int64(epoch))
+		default:
+			seconds, fraction := math.Modf(epoch)
+			return time.Unix(int64(seconds), int64(fraction*float64(time.Second)))
+		}
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999Z07:00"} {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseCaller parses a caller string in the format file:line,
+// or a caller object with file, line and function keys such as
+// the source object of log/slog.
+func parseCaller(raw json.RawMessage) (caller log.Caller) {
+	if raw == nil {
+		return caller
+	}
+
+	var source struct {
+		File     string `json:"file"`
+		Line     int    `json:"line"`
+		Function string `json:"function"`
+	}
+	if err := json.Unmarshal(raw, &source); err == nil {
+		return log.Caller{File: source.File, Line: source.Line, Function: source.Function}
+	}
+
+	s := rawToString(raw)
+	i := strings.LastIndex(s, ":")
+	if i == -1 {
+		return log.Caller{File: s}
+	}
+	line, err := strconv.Atoi(s[i+1:])
+	if err != nil {
+		return log.Caller{File: s}
+	}
+	return log.Caller{File: s[:i], Line: line}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qdm12/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseEntry(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		line  string
+		entry entry
+		ok    bool
+	}{
+		"not JSON": {
+			line: "panic: runtime error",
+		},
+		"JSON array": {
+			line: `[1, 2]`,
+		},
+		"zap": {
+			line: `{"level":"warn","ts":1648461809.5,"logger":"api","caller":"server/handler.go:42",` +
+				`"msg":"slow request","duration":1.2,"path":"/users"}`,
+			entry: entry{
+				level:     log.LevelWarn,
+				time:      time.Unix(1648461809, 500000000),
+				component: "api",
+				message:   "slow request",
+				caller:    log.Caller{File: "server/handler.go", Line: 42},
+				fields: []log.Field{
+					{Key: "duration", Value: "1.2"},
+					{Key: "path", Value: "/users"},
+				},
+			},
+			ok: true,
+		},
+		"slog": {
+			line: `{"time":"2022-03-28T10:03:29.123Z","level":"ERROR","msg":"query failed",` +
+				`"source":{"function":"main.main","file":"/app/main.go","line":10},"err":{"code":7}}`,
+			entry: entry{
+				level:   log.LevelError,
+				time:    time.Date(2022, time.March, 28, 10, 3, 29, 123000000, time.UTC),
+				message: "query failed",
+				caller:  log.Caller{File: "/app/main.go", Line: 10, Function: "main.main"},
+				fields: []log.Field{
+					{Key: "err", Value: `{"code":7}`},
+				},
+			},
+			ok: true,
+		},
+		"logrus": {
+			line: `{"level":"debug","msg":"hello","func":"main.run","file":"/app/run.go:5"}`,
+			entry: entry{
+				level:   log.LevelDebug,
+				message: "hello",
+				caller:  log.Caller{File: "/app/run.go:5", Function: "main.run"},
+			},
+			ok: true,
+		},
+		"pino": {
+			line: `{"level":30,"time":1648461809123,"msg":"listening","pid":1}`,
+			entry: entry{
+				level:   log.LevelInfo,
+				time:    time.UnixMilli(1648461809123),
+				message: "listening",
+				fields:  []log.Field{{Key: "pid", Value: "1"}},
+			},
+			ok: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			e, ok := parseEntry([]byte(testCase.line))
+
+			assert.Equal(t, testCase.ok, ok)
+			assert.Equal(t, testCase.entry.level, e.level)
+			assert.True(t, testCase.entry.time.Equal(e.time),
+				"expected time %s but got %s", testCase.entry.time, e.time)
+			assert.Equal(t, testCase.entry.component, e.component)
+			assert.Equal(t, testCase.entry.message, e.message)
+			assert.Equal(t, testCase.entry.caller, e.caller)
+			assert.Equal(t, testCase.entry.fields, e.fields)
+		})
+	}
+}
+
+func Test_parseLevel(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]log.Level{
+		`"trace"`:   log.LevelDebug,
+		`"INFO"`:    log.LevelInfo,
+		`"notice"`:  log.LevelInfo,
+		`"warning"`: log.LevelWarn,
+		`"fatal"`:   log.LevelError,
+		`20`:        log.LevelDebug,
+		`40`:        log.LevelWarn,
+		`60`:        log.LevelError,
+		`"unknown"`: log.LevelInfo,
+	}
+
+	for raw, expected := range testCases {
+		level := parseLevel([]byte(raw))
+		assert.Equal(t, expected, level, raw)
+	}
+}
+
+func Test_parseTime(t *testing.T) {
+	t.Parallel()
+
+	expected := time.Date(2022, time.March, 28, 10, 3, 29, 0, time.UTC)
+
+	testCases := map[string]time.Time{
+		`"2022-03-28T10:03:29Z"`: expected,
+		`1648461809`:             expected,
+		`1648461809000`:          expected,
+		`1648461809000000`:       expected,
+		`1648461809000000000`:    expected,
+		`"not a time"`:           {},
+		``:                       {},
+	}
+
+	for raw, expectedTime := range testCases {
+		var rawBytes []byte
+		if raw != "" {
+			rawBytes = []byte(raw)
+		}
+		parsed := parseTime(rawBytes)
+		assert.True(t, expectedTime.Equal(parsed), "%s: got %s", raw, parsed)
+	}
+}
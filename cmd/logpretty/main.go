@@ -0,0 +1,185 @@
+// Command logpretty reads newline delimited JSON logs on its
+// standard input and writes them in the human readable format
+// of the log library on its standard output. Lines which are
+// not JSON objects are written unchanged.
+//
+// For example:
+//
+//	kubectl logs my-pod | logpretty -level warn -component api
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/qdm12/log"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(0)
+	default:
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+var (
+	ErrColorModeNotRecognized = errors.New("color mode is not recognized")
+	ErrTimeFormatNotValid     = errors.New("time format is neither a preset nor a time layout")
+)
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flagSet := flag.NewFlagSet("logpretty", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	timeFormat := flagSet.String("time", "rfc3339",
+		"timestamp preset (rfc3339, rfc3339nano, unix, unixms, unixus, unixns, elapsed) "+
+			"or Go time layout, and the empty string to hide timestamps")
+	levelString := flagSet.String("level", "debug", "minimum level to show: debug, info, warn or error")
+	components := flagSet.String("component", "",
+		"comma separated component prefixes to show, for example api,db/postgres")
+	colorMode := flagSet.String("color", "auto", "color mode: auto, always or never")
+	err := flagSet.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	level, err := log.ParseLevel(*levelString)
+	if err != nil {
+		return fmt.Errorf("parsing level flag: %w", err)
+	}
+
+	timestampOption, err := parseTimeFlag(*timeFormat)
+	if err != nil {
+		return fmt.Errorf("parsing time flag: %w", err)
+	}
+
+	switch strings.ToLower(*colorMode) {
+	case "auto":
+	case "always":
+		color.NoColor = false
+	case "never":
+		color.NoColor = true
+	default:
+		return fmt.Errorf("%w: %s", ErrColorModeNotRecognized, *colorMode)
+	}
+
+	var componentPrefixes []string
+	if *components != "" {
+		componentPrefixes = strings.Split(*components, ",")
+	}
+
+	printer := newPrinter(stdout, level, timestampOption)
+	return printer.print(stdin, componentPrefixes)
+}
+
+type printer struct {
+	stdout io.Writer
+	logger *log.Logger
+}
+
+func newPrinter(stdout io.Writer, level log.Level,
+	timestampOption log.Option) *printer {
+	return &printer{
+		stdout: stdout,
+		logger: log.New(
+			log.SetWriters(stdout),
+			log.SetLevel(level),
+			timestampOption,
+			log.SetMultiline(log.MultilineIndent),
+			log.SetCallerFile(true),
+			log.SetCallerLine(true),
+			log.SetCallerFunc(true),
+			log.SetCallerPathMode(log.CallerPathFull),
+			log.SetCallerFuncMode(log.CallerFuncFull),
+		),
+	}
+}
+
+func (p *printer) print(reader io.Reader, componentPrefixes []string) error {
+	bufferedReader := bufio.NewReader(reader)
+	for {
+		line, err := bufferedReader.ReadBytes('\n')
+		if len(line) > 0 {
+			writeErr := p.printLine(line, componentPrefixes)
+			if writeErr != nil {
+				return writeErr
+			}
+		}
+
+		switch {
+		case err == nil:
+		case errors.Is(err, io.EOF):
+			return nil
+		default:
+			return fmt.Errorf("reading input: %w", err)
+		}
+	}
+}
+
+func (p *printer) printLine(line []byte, componentPrefixes []string) error {
+	line = bytes.TrimRight(line, "\r\n")
+
+	e, ok := parseEntry(line)
+	if !ok {
+		_, err := p.stdout.Write(append(line, '\n'))
+		if err != nil {
+			return fmt.Errorf("writing line: %w", err)
+		}
+		return nil
+	}
+
+	if !hasComponentPrefix(e.component, componentPrefixes) {
+		return nil
+	}
+
+	p.logger.LogRecord(log.Record{
+		Level:     e.level,
+		Time:      e.time,
+		Component: e.component,
+		Message:   e.message,
+		Caller:    e.caller,
+		Fields:    e.fields,
+	})
+	return nil
+}
+
+// parseTimeFlag returns the option for the time flag value, which
+// is a timestamp preset, a Go time layout or the empty string to
+// hide timestamps. A value which is not a preset and contains no
+// layout element, such as "unixsec", is rejected.
+func parseTimeFlag(value string) (option log.Option, err error) {
+	option, err = log.ParseTimestampPreset(value)
+	if err == nil {
+		return option, nil
+	}
+
+	reference := time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+	if value != "" && reference.Format(value) == value {
+		return nil, fmt.Errorf("%w: %s", ErrTimeFormatNotValid, value)
+	}
+	return log.SetTimeFormat(value), nil
+}
+
+func hasComponentPrefix(component string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if log.ComponentHasPrefix(component, strings.TrimSpace(prefix), "/") {
+			return true
+		}
+	}
+	return false
+}
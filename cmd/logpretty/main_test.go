@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/qdm12/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_run is not parallel since the color flag sets the
+// global color mode.
+func Test_run(t *testing.T) {
+	input := strings.Join([]string{
+		`{"level":"debug","time":"2022-03-28T10:03:29Z","msg":"starting"}`,
+		`panic: something went wrong`,
+		`{"level":"warn","time":"2022-03-28T10:03:30Z","logger":"api","caller":"server/handler.go:42",` +
+			`"msg":"slow request","path":"/users"}`,
+		`{"level":"error","time":"2022-03-28T10:03:31Z","component":"db/postgres","msg":"query failed"}`,
+		`{"level":"info","time":"2022-03-28T10:03:32Z","component":"apiv2","msg":"ignored"}`,
+		`{"level":"debug","msg":"no time","func":"main.run"}`,
+	}, "\n")
+
+	testCases := map[string]struct {
+		args       []string
+		expected   string
+		errWrapped error
+	}{
+		"default flags": {
+			args: []string{"-color", "never"},
+			expected: "2022-03-28T10:03:29Z DEBUG starting\n" +
+				"panic: something went wrong\n" +
+				"2022-03-28T10:03:30Z WARN [api] slow request path=/users\tserver/handler.go:L42\n" +
+				"2022-03-28T10:03:31Z ERROR [db/postgres] query failed\n" +
+				"2022-03-28T10:03:32Z INFO [apiv2] ignored\n" +
+				"DEBUG no time\tmain.run\n",
+		},
+		"level and components filtering": {
+			args: []string{"-color", "never", "-level", "warn", "-component", "api,db"},
+			expected: "panic: something went wrong\n" +
+				"2022-03-28T10:03:30Z WARN [api] slow request path=/users\tserver/handler.go:L42\n" +
+				"2022-03-28T10:03:31Z ERROR [db/postgres] query failed\n",
+		},
+		"time preset": {
+			args: []string{"-color", "never", "-time", "unix", "-level", "error"},
+			expected: "panic: something went wrong\n" +
+				"1648461811 ERROR [db/postgres] query failed\n",
+		},
+		"time layout": {
+			args: []string{"-color", "never", "-time", "15:04:05", "-level", "error"},
+			expected: "panic: something went wrong\n" +
+				"10:03:31 ERROR [db/postgres] query failed\n",
+		},
+		"bad level": {
+			args:       []string{"-level", "verbose"},
+			errWrapped: log.ErrLevelNotRecognized,
+		},
+		"bad time": {
+			args:       []string{"-time", "unixsec"},
+			errWrapped: ErrTimeFormatNotValid,
+		},
+		"bad color": {
+			args:       []string{"-color", "rainbow"},
+			errWrapped: ErrColorModeNotRecognized,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			stdout := bytes.NewBuffer(nil)
+			stderr := bytes.NewBuffer(nil)
+
+			err := run(testCase.args, strings.NewReader(input), stdout, stderr)
+
+			if testCase.errWrapped != nil {
+				require.ErrorIs(t, err, testCase.errWrapped)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, stdout.String())
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package log
+
+import "strings"
+
+const defaultComponentSeparator = "/"
+
+func joinComponents(parent, child, separator string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	default:
+		return parent + separator + child
+	}
+}
+
+// ComponentHasPrefix returns true if the component path is
+// equal to the prefix path, or is nested under it, using the
+// separator given. For example with the separator "/", the
+// component "api/auth" has the prefixes "api" and "api/auth",
+// but not "ap".
+func ComponentHasPrefix(component, prefix, separator string) bool {
+	if !strings.HasPrefix(component, prefix) {
+		return false
+	}
+	rest := component[len(prefix):]
+	return rest == "" || prefix == "" || strings.HasPrefix(rest, separator)
+}
+
+// DropComponents returns a hook dropping records whose component
+// path has one of the prefixes given, as defined by the function
+// ComponentHasPrefix with the separator given.
+func DropComponents(separator string, prefixes ...string) Hook {
+	return HookFunc(func(record Record) (processed Record, keep bool) {
+		for _, prefix := range prefixes {
+			if ComponentHasPrefix(record.Component, prefix, separator) {
+				return record, false
+			}
+		}
+		return record, true
+	})
+}
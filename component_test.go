@@ -0,0 +1,110 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ComponentHasPrefix(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		component string
+		prefix    string
+		separator string
+		hasPrefix bool
+	}{
+		"empty prefix": {
+			component: "api",
+			separator: "/",
+			hasPrefix: true,
+		},
+		"equal": {
+			component: "api/auth",
+			prefix:    "api/auth",
+			separator: "/",
+			hasPrefix: true,
+		},
+		"parent prefix": {
+			component: "api/auth/jwt",
+			prefix:    "api",
+			separator: "/",
+			hasPrefix: true,
+		},
+		"partial name": {
+			component: "api/auth",
+			prefix:    "ap",
+			separator: "/",
+		},
+		"other separator": {
+			component: "api.auth",
+			prefix:    "api",
+			separator: ".",
+			hasPrefix: true,
+		},
+		"not prefix": {
+			component: "db",
+			prefix:    "api",
+			separator: "/",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hasPrefix := ComponentHasPrefix(testCase.component,
+				testCase.prefix, testCase.separator)
+
+			assert.Equal(t, testCase.hasPrefix, hasPrefix)
+		})
+	}
+}
+
+func Test_Logger_AppendComponent(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+
+	api := New(SetWriters(buffer), SetComponent("api"),
+		AddHooks(DropComponents("/", "api/internal")))
+	auth := api.New(AppendComponent("auth"))
+	jwt := auth.New(AppendComponent("jwt"))
+	replaced := auth.New(SetComponent("db"))
+	internal := api.New(AppendComponent("internal"), AppendComponent("cache"))
+	dotted := New(SetWriters(buffer), SetComponentSeparator("."),
+		AppendComponent("a"), AppendComponent("b"))
+	patched := New(SetWriters(buffer))
+	patched.Patch(AppendComponent("x"))
+
+	api.Info("message")
+	auth.Info("message")
+	jwt.Info("message")
+	replaced.Info("message")
+	internal.Info("message")
+	dotted.Info("message")
+	patched.Info("message")
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+
+	expectedRegexes := []string{
+		timePrefixRegex + `INFO \[api\] message$`,
+		timePrefixRegex + `INFO \[api/auth\] message$`,
+		timePrefixRegex + `INFO \[api/auth/jwt\] message$`,
+		timePrefixRegex + `INFO \[db\] message$`,
+		timePrefixRegex + `INFO \[a\.b\] message$`,
+		timePrefixRegex + `INFO \[x\] message$`,
+	}
+	require.Equal(t, len(expectedRegexes), len(lines))
+	for i := range lines {
+		regex := regexp.MustCompile(expectedRegexes[i])
+		assert.True(t, regex.MatchString(lines[i]),
+			"line %q does not match regex %q", lines[i], expectedRegexes[i])
+	}
+}
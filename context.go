@@ -0,0 +1,9 @@
+package log
+
+import "context"
+
+// ContextExtractor extracts fields from a context, to be attached
+// to records logged with the context-aware log methods such as
+// InfoContext. For example, the otel subpackage provides an
+// extractor for the OpenTelemetry trace and span IDs.
+type ContextExtractor func(ctx context.Context) (fields []Field)
@@ -0,0 +1,52 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testContextKey struct{}
+
+func Test_Logger_contextLogging(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+
+	extractor := func(ctx context.Context) []Field {
+		requestID, ok := ctx.Value(testContextKey{}).(string)
+		if !ok {
+			return nil
+		}
+		return []Field{{Key: "request_id", Value: requestID}}
+	}
+
+	logger := New(SetWriters(buffer), SetLevel(LevelDebug),
+		AddContextExtractors(extractor))
+	ctx := context.WithValue(context.Background(), testContextKey{}, "abc")
+
+	testCases := map[string]struct {
+		log   func(ctx context.Context, format string, args ...interface{})
+		level string
+	}{
+		"debug": {log: logger.DebugContext, level: "DEBUG"},
+		"info":  {log: logger.InfoContext, level: "INFO"},
+		"warn":  {log: logger.WarnContext, level: "WARN"},
+		"error": {log: logger.ErrorContext, level: "ERROR"},
+	}
+
+	for name, testCase := range testCases {
+		testCase.log(ctx, "message %d", 1)
+		regex := regexp.MustCompile(timePrefixRegex + testCase.level +
+			" message 1 request_id=abc\n$")
+		assert.True(t, regex.MatchString(buffer.String()), name)
+		buffer.Reset()
+	}
+
+	logger.InfoContext(context.Background(), "no request")
+	regex := regexp.MustCompile(timePrefixRegex + "INFO no request\n$")
+	assert.True(t, regex.MatchString(buffer.String()))
+}
@@ -0,0 +1,26 @@
+package log
+
+// FlushDuplicates logs the "last message repeated N times"
+// summary of any series of identical records currently being
+// suppressed. It should be called before the program exits
+// if the SetDeduplication option is used.
+// It is a no-op if deduplication is not enabled.
+func (l *Logger) FlushDuplicates() {
+	if l.members != nil {
+		for _, member := range l.members {
+			if flusher, ok := member.(interface{ FlushDuplicates() }); ok {
+				flusher.FlushDuplicates()
+			}
+		}
+		return
+	}
+
+	l.settingsMutex.RLock()
+	deduplicator := l.settings.deduplicator
+	l.settingsMutex.RUnlock()
+
+	if deduplicator == nil {
+		return
+	}
+	deduplicator.Flush()
+}
@@ -0,0 +1,43 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Logger_FlushDuplicates(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+	parent := New(SetWriters(buffer), SetDeduplication(time.Hour))
+	child := parent.New(SetComponent("child"))
+
+	parent.Warnf("dependency %s is down", "x")
+	parent.Warnf("dependency %s is down", "x")
+	parent.Warnf("dependency %s is down", "x")
+	child.Warnf("dependency %s is down", "x")
+	child.Warnf("dependency %s is down", "x")
+	child.FlushDuplicates()
+	parent.FlushDuplicates()
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+
+	expectedRegexes := []string{
+		timePrefixRegex + "WARN dependency x is down$",
+		timePrefixRegex + "WARN last message repeated 2 times$",
+		timePrefixRegex + `WARN \[child\] dependency x is down$`,
+		timePrefixRegex + `WARN \[child\] last message repeated 1 times$`,
+	}
+	require.Equal(t, len(expectedRegexes), len(lines))
+	for i := range lines {
+		regex := regexp.MustCompile(expectedRegexes[i])
+		assert.True(t, regex.MatchString(lines[i]),
+			"line %q does not match regex %q", lines[i], expectedRegexes[i])
+	}
+}
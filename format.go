@@ -0,0 +1,181 @@
+package log
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/qdm12/log/internal/caller"
+)
+
+// formattedLine is a formatted log line, with its caller
+// kept apart such that it can be right aligned for writers
+// writing to a terminal.
+type formattedLine struct {
+	// text is the line text without the caller nor the
+	// trailing newline.
+	text string
+	// caller is the caller string, and is empty if no
+	// caller information is shown.
+	caller string
+	// lineColor is the color for the entire line,
+	// and is nil if the line is not colored as a whole.
+	lineColor *color.Color
+	// lastLineWidth and callerWidth are the printed widths
+	// of the last line of text and of the caller, excluding
+	// color escape sequences.
+	lastLineWidth    int
+	callerWidth      int
+	rightAlignCaller bool
+}
+
+// String returns the line with the caller separated
+// from the text with a tab.
+func (f formattedLine) String() string {
+	line := f.text
+	if f.caller != "" {
+		line += "\t" + f.caller
+	}
+	return f.paintLine(line) + "\n"
+}
+
+// forTerminal returns the line with the caller right aligned
+// to the terminal width given, if caller right alignment is
+// enabled. It falls back on the String method if the terminal
+// width is 0 or too small to fit the caller.
+func (f formattedLine) forTerminal(width int) string {
+	padding := width - f.lastLineWidth - f.callerWidth
+	if !f.rightAlignCaller || f.caller == "" || width == 0 || padding < 1 {
+		return f.String()
+	}
+	return f.paintLine(f.text+strings.Repeat(" ", padding)+f.caller) + "\n"
+}
+
+func (f formattedLine) paintLine(line string) string {
+	if f.lineColor == nil {
+		return line
+	}
+	return f.lineColor.Sprint(line)
+}
+
+func formatRecord(settings settings, record Record) (formatted formattedLine) {
+	theme := settings.theme
+	style := theme.levelStyle(record.Level)
+
+	paintPart := paint
+	if style.ColorLine && style.Color != nil {
+		// parts are not colored individually since
+		// the whole line is colored.
+		formatted.lineColor = style.Color
+		paintPart = func(_ *color.Color, s string) string { return s }
+	}
+
+	// prefixWidth is the printed width of the prefix,
+	// excluding color escape sequences.
+	var prefix string
+	var prefixWidth int
+
+	start := settings.start
+	if start.IsZero() {
+		start = processStart
+	}
+	var timeString string
+	if !record.Time.IsZero() {
+		timeString = formatTimestamp(*settings.timestampMode,
+			*settings.timeFormat, record.Time, start)
+	}
+	if timeString != "" {
+		prefix += paintPart(theme.Timestamp, timeString) + " "
+		prefixWidth += len(timeString) + 1
+	}
+
+	levelLabel := theme.levelLabel(record.Level)
+	if settings.aligner != nil {
+		levelLabel = padRight(levelLabel, settings.aligner.levelWidth(theme))
+	}
+	prefix += paintPart(style.Color, levelLabel) + " "
+	prefixWidth += utf8.RuneCountInString(levelLabel) + 1
+
+	componentWidth := 0
+	if settings.aligner != nil {
+		componentWidth = settings.aligner.componentWidth(record.Component)
+	}
+	if record.Component != "" {
+		tag := "[" + record.Component + "]"
+		padding := padRight("", componentWidth-utf8.RuneCountInString(record.Component)) + " "
+		prefix += paintPart(theme.componentColor(record.Component), tag) + padding
+		prefixWidth += utf8.RuneCountInString(tag) + len(padding)
+	} else if componentWidth > 0 {
+		padding := strings.Repeat(" ", componentWidth+len("[] "))
+		prefix += padding
+		prefixWidth += len(padding)
+	}
+
+	message := record.Message
+	if *settings.multiline != MultilineRaw {
+		message = strings.TrimSuffix(message, "\n")
+	}
+	for _, field := range record.Fields {
+		message += " " + paintPart(theme.FieldKey, field.Key) + "=" + field.valueString()
+	}
+
+	formatted.text = prefix + formatMultiline(*settings.multiline, message, prefix, prefixWidth)
+	formatted.lastLineWidth = printedWidth(formatted.text[strings.LastIndex(formatted.text, "\n")+1:])
+
+	callerString := caller.Format(settings.caller, record.Caller.toFrame())
+	if callerString != "" {
+		formatted.caller = paintPart(theme.Caller, callerString)
+		formatted.callerWidth = utf8.RuneCountInString(callerString)
+	}
+
+	formatted.rightAlignCaller = settings.aligner != nil &&
+		settings.aligner.settings.RightAlignCaller
+
+	return formatted
+}
+
+func padRight(s string, width int) string {
+	padding := width - utf8.RuneCountInString(s)
+	if padding <= 0 {
+		return s
+	}
+	return s + strings.Repeat(" ", padding)
+}
+
+// printedWidth returns the number of runes of the string
+// given, excluding ANSI color escape sequences.
+func printedWidth(s string) (width int) {
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case inEscape:
+			// color escape sequences end with a letter
+			inEscape = !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+		case r == '\x1b':
+			inEscape = true
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+func formatMultiline(mode MultilineMode, message, prefix string,
+	prefixWidth int) string {
+	if !strings.Contains(message, "\n") {
+		return message
+	}
+
+	switch mode {
+	case MultilineIndent:
+		indent := strings.Repeat(" ", prefixWidth)
+		return strings.ReplaceAll(message, "\n", "\n"+indent)
+	case MultilinePrefix:
+		return strings.ReplaceAll(message, "\n", "\n"+prefix)
+	case MultilineEscape:
+		message = strings.ReplaceAll(message, "\r", `\r`)
+		return strings.ReplaceAll(message, "\n", `\n`)
+	default:
+		return message
+	}
+}
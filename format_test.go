@@ -0,0 +1,77 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_formatRecord(t *testing.T) {
+	t.Parallel()
+
+	record := Record{
+		Level:     LevelInfo,
+		Time:      time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC),
+		Component: "db",
+		Message:   "SELECT *\nFROM table\n",
+		Fields:    []Field{{Key: "rows", Value: 2}},
+	}
+
+	infoString := LevelInfo.ColoredString()
+
+	testCases := map[string]struct {
+		multiline MultilineMode
+		line      string
+	}{
+		"raw": {
+			multiline: MultilineRaw,
+			line: "2022-03-04T05:06:07Z " + infoString + " [db] SELECT *\n" +
+				"FROM table\n rows=2\n",
+		},
+		"indent": {
+			multiline: MultilineIndent,
+			line: "2022-03-04T05:06:07Z " + infoString + " [db] SELECT *\n" +
+				"                               FROM table rows=2\n",
+		},
+		"prefix": {
+			multiline: MultilinePrefix,
+			line: "2022-03-04T05:06:07Z " + infoString + " [db] SELECT *\n" +
+				"2022-03-04T05:06:07Z " + infoString + " [db] FROM table rows=2\n",
+		},
+		"escape": {
+			multiline: MultilineEscape,
+			line:      "2022-03-04T05:06:07Z " + infoString + ` [db] SELECT *\nFROM table rows=2` + "\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			settings := settings{
+				timeFormat:    stringPtr(time.RFC3339),
+				multiline:     multilinePtr(testCase.multiline),
+				timestampMode: timestampModePtr(TimestampLayout),
+				theme:         themePtr(DefaultTheme()),
+				caller:        newCallerSettings(false, false, false),
+			}
+
+			line := formatRecord(settings, record).String()
+
+			assert.Equal(t, testCase.line, line)
+		})
+	}
+}
+
+func Test_formatMultiline_colors(t *testing.T) {
+	t.Parallel()
+
+	// The indentation width ignores color escape sequences.
+	prefix := color.New(color.FgRed).Sprint("ERROR") + " "
+	message := formatMultiline(MultilineIndent, "a\nb", prefix, len("ERROR "))
+
+	assert.Equal(t, "a\n      b", message)
+}
@@ -0,0 +1,90 @@
+package gelf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	chunkHeaderSize = 12
+	maxChunks       = 128
+)
+
+// chunkMagic are the magic bytes starting each GELF chunk.
+var chunkMagic = [2]byte{0x1e, 0x0f} //nolint:gochecknoglobals
+
+var ErrMessageTooLarge = errors.New("message is too large")
+
+// chunk splits the data in GELF chunks of at most chunkSize
+// bytes, each starting with the magic bytes, a message ID
+// shared by all the chunks, the sequence number and the
+// sequence count. It returns the data as a single chunk if
+// it fits in chunkSize bytes.
+func chunk(data []byte, chunkSize int) (chunks [][]byte, err error) {
+	if len(data) <= chunkSize {
+		return [][]byte{data}, nil
+	}
+
+	payloadSize := chunkSize - chunkHeaderSize
+	count := (len(data) + payloadSize - 1) / payloadSize
+	if count > maxChunks {
+		return nil, fmt.Errorf("%w: %d bytes needs %d chunks, exceeding the maximum of %d",
+			ErrMessageTooLarge, len(data), count, maxChunks)
+	}
+
+	var messageID [8]byte
+	_, err = rand.Read(messageID[:])
+	if err != nil {
+		return nil, fmt.Errorf("generating message ID: %w", err)
+	}
+
+	chunks = make([][]byte, count)
+	for i := range chunks {
+		start := i * payloadSize
+		end := start + payloadSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		c := make([]byte, 0, chunkHeaderSize+end-start)
+		c = append(c, chunkMagic[:]...)
+		c = append(c, messageID[:]...)
+		c = append(c, byte(i), byte(count))
+		c = append(c, data[start:end]...)
+		chunks[i] = c
+	}
+
+	return chunks, nil
+}
+
+func compress(data []byte, compression Compression) (compressed []byte, err error) {
+	var buffer bytes.Buffer
+	var writer io.WriteCloser
+	switch compression {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		writer = gzip.NewWriter(&buffer)
+	case CompressionZlib:
+		writer = zlib.NewWriter(&buffer)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrCompressionNotSupported, compression)
+	}
+
+	_, err = writer.Write(data)
+	if err != nil {
+		return nil, fmt.Errorf("compressing: %w", err)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("closing compressor: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,53 @@
+package gelf
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_chunk(t *testing.T) {
+	t.Parallel()
+
+	t.Run("single chunk", func(t *testing.T) {
+		t.Parallel()
+
+		chunks, err := chunk([]byte("data"), 100)
+
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("data")}, chunks)
+	})
+
+	t.Run("multiple chunks", func(t *testing.T) {
+		t.Parallel()
+
+		data := []byte("01234567890123456789")
+		const chunkSize = chunkHeaderSize + 4
+
+		chunks, err := chunk(data, chunkSize)
+
+		require.NoError(t, err)
+		require.Len(t, chunks, 5)
+		var reassembled []byte
+		for i, c := range chunks {
+			assert.Equal(t, chunkMagic[:], c[:2])
+			assert.Equal(t, chunks[0][2:10], c[2:10])
+			assert.Equal(t, byte(i), c[10])
+			assert.Equal(t, byte(5), c[11])
+			reassembled = append(reassembled, c[chunkHeaderSize:]...)
+		}
+		assert.Equal(t, data, reassembled)
+	})
+
+	t.Run("too many chunks", func(t *testing.T) {
+		t.Parallel()
+
+		data := bytes.Repeat([]byte{'a'}, maxChunks+1)
+
+		_, err := chunk(data, chunkHeaderSize+1)
+
+		assert.ErrorIs(t, err, ErrMessageTooLarge)
+	})
+}
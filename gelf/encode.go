@@ -0,0 +1,107 @@
+package gelf
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/qdm12/log"
+)
+
+// LevelToSyslog returns the syslog level used in GELF
+// messages corresponding to the log level given.
+func LevelToSyslog(level log.Level) int {
+	const (
+		syslogError   = 3
+		syslogWarning = 4
+		syslogNotice  = 5
+		syslogInfo    = 6
+		syslogDebug   = 7
+	)
+
+	switch level {
+	case log.LevelError:
+		return syslogError
+	case log.LevelWarn:
+		return syslogWarning
+	case log.LevelInfo:
+		return syslogInfo
+	case log.LevelDebug:
+		return syslogDebug
+	default:
+		return syslogNotice
+	}
+}
+
+// Encode encodes the record as a GELF 1.1 JSON message, using
+// the host given. The record component and fields are encoded
+// as additional fields prefixed with an underscore, and the
+// caller is encoded in the file and line fields.
+func Encode(record log.Record, host string) (data []byte, err error) {
+	const nanosecondsPerSecond = 1e9
+	message := map[string]interface{}{
+		"version":       "1.1",
+		"host":          host,
+		"short_message": record.Message,
+		"timestamp":     float64(record.Time.UnixNano()) / nanosecondsPerSecond,
+		"level":         LevelToSyslog(record.Level),
+	}
+
+	if i := strings.IndexByte(record.Message, '\n'); i >= 0 {
+		message["short_message"] = record.Message[:i]
+		message["full_message"] = record.Message
+	}
+
+	if record.Component != "" {
+		message["_component"] = record.Component
+	}
+
+	if record.Caller.File != "" {
+		message["file"] = record.Caller.File
+		message["line"] = record.Caller.Line
+		if record.Caller.Function != "" {
+			message["_function"] = record.Caller.Function
+		}
+	}
+
+	for _, field := range record.Fields {
+		value := field.Value
+		switch value.(type) {
+		case string, bool,
+			int, int8, int16, int32, int64,
+			uint, uint8, uint16, uint32, uint64,
+			float32, float64:
+		default:
+			value = fmt.Sprint(value)
+		}
+		message[additionalFieldName(field.Key)] = value
+	}
+
+	data, err = json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("encoding JSON: %w", err)
+	}
+	return data, nil
+}
+
+// additionalFieldName returns the GELF additional field name
+// for the key given, prefixed with an underscore and with
+// characters other than letters, digits, underscores, dashes
+// and dots replaced by underscores. The reserved `_id` field
+// name is changed to `__id`.
+func additionalFieldName(key string) string {
+	name := "_" + strings.Map(func(r rune) rune {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'),
+			(r >= '0' && r <= '9'), r == '_', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+
+	if name == "_id" {
+		name = "__id"
+	}
+	return name
+}
@@ -0,0 +1,64 @@
+package gelf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qdm12/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Encode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		record log.Record
+		json   string
+	}{
+		"minimal record": {
+			record: log.Record{
+				Level:   log.LevelInfo,
+				Time:    time.Unix(1600000000, 500000000),
+				Message: "message",
+			},
+			json: `{"host":"host","level":6,"short_message":"message",` +
+				`"timestamp":1600000000.5,"version":"1.1"}`,
+		},
+		"full record": {
+			record: log.Record{
+				Level:     log.LevelError,
+				Time:      time.Unix(1600000000, 0),
+				Component: "http",
+				Message:   "first line\nsecond line",
+				Caller: log.Caller{
+					File:     "/a/main.go",
+					Line:     12,
+					Function: "main.main",
+				},
+				Fields: []log.Field{
+					{Key: "id", Value: 1},
+					{Key: "user name", Value: "john"},
+					{Key: "duration", Value: time.Second},
+				},
+			},
+			json: `{"__id":1,"_component":"http","_duration":"1s",` +
+				`"_function":"main.main","_user_name":"john",` +
+				`"file":"/a/main.go","full_message":"first line\nsecond line",` +
+				`"host":"host","level":3,"line":12,"short_message":"first line",` +
+				`"timestamp":1600000000,"version":"1.1"}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := Encode(testCase.record, "host")
+
+			require.NoError(t, err)
+			assert.JSONEq(t, testCase.json, string(data))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package gelf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Compression is the compression used for UDP messages.
+type Compression uint8
+
+const (
+	// CompressionNone disables compression.
+	CompressionNone Compression = iota
+	// CompressionGzip compresses messages with gzip.
+	CompressionGzip
+	// CompressionZlib compresses messages with zlib.
+	CompressionZlib
+)
+
+// Settings contains settings for the GELF writer.
+type Settings struct {
+	// Network is the network to use, "udp" or "tcp".
+	// It defaults to "udp".
+	Network string
+	// Address is the address of the GELF input,
+	// for example "127.0.0.1:12201". It cannot be empty.
+	Address string
+	// Host is the host field of GELF messages.
+	// It defaults to the hostname reported by the kernel.
+	Host string
+	// Compression is the compression to use for UDP messages.
+	// Messages sent over TCP are never compressed, as required
+	// by the GELF specification.
+	// It defaults to CompressionNone.
+	Compression Compression
+	// ChunkSize is the maximum size of UDP datagrams, above
+	// which messages are split in chunks.
+	// It defaults to 1420 bytes.
+	ChunkSize int
+}
+
+func (s *Settings) setDefaults() {
+	if s.Network == "" {
+		s.Network = "udp"
+	}
+
+	if s.Host == "" {
+		s.Host, _ = os.Hostname()
+	}
+
+	if s.ChunkSize == 0 {
+		const defaultChunkSize = 1420
+		s.ChunkSize = defaultChunkSize
+	}
+}
+
+var (
+	ErrNetworkNotSupported     = errors.New("network is not supported")
+	ErrAddressNotSet           = errors.New("address is not set")
+	ErrCompressionNotSupported = errors.New("compression is not supported")
+	ErrChunkSizeTooSmall       = errors.New("chunk size is too small")
+)
+
+func (s Settings) validate() (err error) {
+	switch s.Network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+	default:
+		return fmt.Errorf("%w: %s", ErrNetworkNotSupported, s.Network)
+	}
+
+	if s.Address == "" {
+		return fmt.Errorf("%w", ErrAddressNotSet)
+	}
+
+	if s.Compression > CompressionZlib {
+		return fmt.Errorf("%w: %d", ErrCompressionNotSupported, s.Compression)
+	}
+
+	const minChunkSize = chunkHeaderSize + 1
+	if s.ChunkSize < minChunkSize {
+		return fmt.Errorf("%w: %d must be at least %d",
+			ErrChunkSizeTooSmall, s.ChunkSize, minChunkSize)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package gelf
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qdm12/log"
+)
+
+var _ log.RecordWriter = (*Writer)(nil)
+
+// Writer is a GELF writer sending records to a GELF input
+// over UDP or TCP, which can be given to a logger with the
+// log.SetWriters or log.AddWriters options.
+// It is thread safe to use.
+type Writer struct {
+	settings Settings
+	udp      bool
+
+	mutex  sync.Mutex
+	conn   net.Conn
+	closed bool
+}
+
+// ErrWriterClosed is returned when writing to a closed writer.
+var ErrWriterClosed = errors.New("GELF writer is closed")
+
+// New creates a GELF writer connected to the GELF input.
+func New(settings Settings) (writer *Writer, err error) {
+	settings.setDefaults()
+	err = settings.validate()
+	if err != nil {
+		return nil, fmt.Errorf("validating settings: %w", err)
+	}
+
+	writer = &Writer{
+		settings: settings,
+		udp:      strings.HasPrefix(settings.Network, "udp"),
+	}
+
+	writer.conn, err = net.Dial(settings.Network, settings.Address)
+	if err != nil {
+		return nil, fmt.Errorf("dialing GELF input: %w", err)
+	}
+
+	return writer, nil
+}
+
+// WriteRecord encodes and sends the record as a GELF message.
+func (w *Writer) WriteRecord(record log.Record) (err error) {
+	data, err := Encode(record, w.settings.Host)
+	if err != nil {
+		return err
+	}
+
+	var packets [][]byte
+	if w.udp {
+		data, err = compress(data, w.settings.Compression)
+		if err != nil {
+			return err
+		}
+
+		packets, err = chunk(data, w.settings.ChunkSize)
+		if err != nil {
+			return err
+		}
+	} else {
+		// TCP messages are delimited by a null byte.
+		packets = [][]byte{append(data, 0)}
+	}
+
+	return w.send(packets)
+}
+
+// Write sends the data given as a GELF message at the
+// info level, with any trailing new line removed.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	record := log.Record{
+		Level:   log.LevelInfo,
+		Time:    time.Now(),
+		Message: strings.TrimSuffix(string(p), "\n"),
+	}
+
+	err = w.WriteRecord(record)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *Writer) send(packets [][]byte) (err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return fmt.Errorf("%w", ErrWriterClosed)
+	}
+
+	if w.conn == nil {
+		w.conn, err = net.Dial(w.settings.Network, w.settings.Address)
+		if err != nil {
+			return fmt.Errorf("dialing GELF input: %w", err)
+		}
+	}
+
+	for _, packet := range packets {
+		_, err = w.conn.Write(packet)
+		if err != nil {
+			// reconnect on the next write
+			_ = w.conn.Close()
+			w.conn = nil
+			return fmt.Errorf("writing to GELF input: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the connection to the GELF input.
+func (w *Writer) Close() (err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.closed = true
+	if w.conn == nil {
+		return nil
+	}
+
+	err = w.conn.Close()
+	w.conn = nil
+	if err != nil {
+		return fmt.Errorf("closing connection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package gelf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qdm12/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readUDPMessage reads and reassembles a GELF message from the
+// UDP listener, decompressing it if needed.
+func readUDPMessage(t *testing.T, listener net.PacketConn) (message map[string]interface{}) {
+	t.Helper()
+
+	_ = listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var chunks [][]byte
+	for {
+		buffer := make([]byte, 65536)
+		n, _, err := listener.ReadFrom(buffer)
+		require.NoError(t, err)
+		packet := buffer[:n]
+
+		if !bytes.HasPrefix(packet, chunkMagic[:]) {
+			chunks = [][]byte{packet}
+			break
+		}
+
+		chunks = append(chunks, packet)
+		count := int(packet[11])
+		if len(chunks) == count {
+			sort.Slice(chunks, func(i, j int) bool { return chunks[i][10] < chunks[j][10] })
+			for i := range chunks {
+				chunks[i] = chunks[i][chunkHeaderSize:]
+			}
+			break
+		}
+	}
+
+	data := bytes.Join(chunks, nil)
+
+	var reader io.Reader = bytes.NewReader(data)
+	switch {
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		gzipReader, err := gzip.NewReader(reader)
+		require.NoError(t, err)
+		reader = gzipReader
+	case data[0] == 0x78:
+		zlibReader, err := zlib.NewReader(reader)
+		require.NoError(t, err)
+		reader = zlibReader
+	}
+
+	err := json.NewDecoder(reader).Decode(&message)
+	require.NoError(t, err)
+	return message
+}
+
+func Test_Writer_UDP(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		compression Compression
+		message     string
+	}{
+		"uncompressed": {
+			message: "some message",
+		},
+		"gzip chunked": {
+			compression: CompressionGzip,
+			message:     randomString(t, 4000),
+		},
+		"zlib": {
+			compression: CompressionZlib,
+			message:     "some message",
+		},
+		"uncompressed chunked": {
+			message: strings.Repeat("a", 4000),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer listener.Close()
+
+			writer, err := New(Settings{
+				Address:     listener.LocalAddr().String(),
+				Host:        "host",
+				Compression: testCase.compression,
+				ChunkSize:   1000,
+			})
+			require.NoError(t, err)
+			defer writer.Close()
+
+			logger := log.New(log.SetWriters(writer), log.SetComponent("component"))
+			logger.Warn(testCase.message)
+
+			message := readUDPMessage(t, listener)
+
+			assert.Equal(t, "1.1", message["version"])
+			assert.Equal(t, "host", message["host"])
+			assert.Equal(t, testCase.message, message["short_message"])
+			assert.Equal(t, float64(4), message["level"])
+			assert.Equal(t, "component", message["_component"])
+		})
+	}
+}
+
+func Test_Writer_TCP(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	writer, err := New(Settings{
+		Network:     "tcp",
+		Address:     listener.Addr().String(),
+		Host:        "host",
+		Compression: CompressionGzip, // ignored for TCP
+	})
+	require.NoError(t, err)
+	defer writer.Close()
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = writer.Write([]byte("first\n"))
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("second\n"))
+	require.NoError(t, err)
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+	for _, expected := range []string{"first", "second"} {
+		data, err := reader.ReadBytes(0)
+		require.NoError(t, err)
+
+		var message map[string]interface{}
+		err = json.Unmarshal(data[:len(data)-1], &message)
+		require.NoError(t, err)
+		assert.Equal(t, expected, message["short_message"])
+	}
+}
+
+func randomString(t *testing.T, length int) string {
+	t.Helper()
+
+	// a pseudo random string which does not compress much
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	b := make([]byte, length)
+	state := uint32(1)
+	for i := range b {
+		state = state*1664525 + 1013904223
+		b[i] = alphabet[state>>24%uint32(len(alphabet))]
+	}
+	return string(b)
+}
@@ -11,10 +11,20 @@ func levelPtr(l Level) *Level { return &l }
 
 func stringPtr(s string) *string { return &s }
 
+func multilinePtr(m MultilineMode) *MultilineMode { return &m }
+
+func timestampModePtr(m TimestampMode) *TimestampMode { return &m }
+
+func themePtr(t Theme) *Theme { return &t }
+
 func newCallerSettings(file, line, funC bool) caller.Settings {
+	pathMode := caller.PathBase
+	funcMode := caller.FuncShort
 	return caller.Settings{
-		File: &file,
-		Line: &line,
-		Func: &funC,
+		File:     &file,
+		Line:     &line,
+		Func:     &funC,
+		PathMode: &pathMode,
+		FuncMode: &funcMode,
 	}
 }
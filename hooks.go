@@ -0,0 +1,29 @@
+package log
+
+// Hook is the interface to inspect, modify or drop
+// records before they get formatted and written.
+type Hook interface {
+	// Process is called for each record, and returns the
+	// record to use, which can be modified, and whether to
+	// keep it. If keep is false, the record is not written
+	// and no further hook is called.
+	Process(record Record) (processed Record, keep bool)
+}
+
+// HookFunc is a function implementing the Hook interface.
+type HookFunc func(record Record) (processed Record, keep bool)
+
+// Process calls the hook function with the record given.
+func (f HookFunc) Process(record Record) (processed Record, keep bool) {
+	return f(record)
+}
+
+func runHooks(hooks []Hook, record Record) (processed Record, keep bool) {
+	for _, hook := range hooks {
+		record, keep = hook.Process(record)
+		if !keep {
+			return record, false
+		}
+	}
+	return record, true
+}
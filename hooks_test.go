@@ -0,0 +1,78 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFieldHook struct {
+	key   string
+	value string
+}
+
+func (h testFieldHook) Process(record Record) (processed Record, keep bool) {
+	record.Fields = append(record.Fields, Field{Key: h.key, Value: h.value})
+	return record, true
+}
+
+func Test_Logger_hooks(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+
+	dropVendor := HookFunc(func(record Record) (Record, bool) {
+		return record, record.Component != "vendor"
+	})
+	upperCase := HookFunc(func(record Record) (Record, bool) {
+		record.Message = strings.ToUpper(record.Message)
+		return record, true
+	})
+
+	parent := New(SetWriters(buffer),
+		AddHooks(testFieldHook{key: "tenant", value: "acme"}, dropVendor))
+	child := parent.New(SetComponent("child"), AddHooks(upperCase))
+	vendor := parent.New(SetComponent("vendor"))
+
+	parent.Info("parent message")
+	child.Infof("child %s", "message")
+	vendor.Info("vendor message")
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+
+	expectedRegexes := []string{
+		timePrefixRegex + "INFO parent message tenant=acme$",
+		timePrefixRegex + `INFO \[child\] CHILD MESSAGE tenant=acme$`,
+	}
+	require.Equal(t, len(expectedRegexes), len(lines))
+	for i := range lines {
+		regex := regexp.MustCompile(expectedRegexes[i])
+		assert.True(t, regex.MatchString(lines[i]),
+			"line %q does not match regex %q", lines[i], expectedRegexes[i])
+	}
+}
+
+func Test_runHooks(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	counter := HookFunc(func(record Record) (Record, bool) {
+		calls++
+		return record, true
+	})
+	drop := HookFunc(func(record Record) (Record, bool) {
+		return record, false
+	})
+
+	record, keep := runHooks(nil, Record{Message: "a"})
+	assert.True(t, keep)
+	assert.Equal(t, Record{Message: "a"}, record)
+
+	_, keep = runHooks([]Hook{counter, drop, counter}, Record{})
+	assert.False(t, keep)
+	assert.Equal(t, 1, calls)
+}
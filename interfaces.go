@@ -1,6 +1,14 @@
 package log
 
-var _ LoggerInterface = (*Logger)(nil)
+import (
+	"context"
+	"io"
+)
+
+var (
+	_ LoggerInterface = (*Logger)(nil)
+	_ ContextLogger   = (*Logger)(nil)
+)
 
 type LoggerInterface interface {
 	LeveledLogger
@@ -20,6 +28,15 @@ type LeveledLogger interface {
 	Errorf(format string, args ...interface{})
 }
 
+// ContextLogger is the interface to log at different levels
+// with fields extracted from a context.
+type ContextLogger interface {
+	DebugContext(ctx context.Context, format string, args ...interface{})
+	InfoContext(ctx context.Context, format string, args ...interface{})
+	WarnContext(ctx context.Context, format string, args ...interface{})
+	ErrorContext(ctx context.Context, format string, args ...interface{})
+}
+
 // LoggerPatcher is the interface to update the current logger.
 type LoggerPatcher interface {
 	Patch(options ...Option)
@@ -29,3 +46,12 @@ type LoggerPatcher interface {
 type ChildConstructor interface {
 	New(options ...Option) *Logger
 }
+
+// RecordWriter is the interface for writers receiving
+// structured records instead of formatted lines.
+// If a writer given to the logger implements it, its
+// WriteRecord method is called instead of its Write method.
+type RecordWriter interface {
+	io.Writer
+	WriteRecord(record Record) error
+}
@@ -2,27 +2,37 @@ package caller
 
 import (
 	"fmt"
-	"path/filepath"
 	"runtime"
 	"strings"
 )
 
 type Settings struct {
-	File *bool
-	Line *bool
-	Func *bool
+	File     *bool
+	Line     *bool
+	Func     *bool
+	PathMode *PathMode
+	FuncMode *FuncMode
+	// Skip is the number of additional stack frames to skip
+	// when determining the caller. It is added to the skip of
+	// the settings it overrides.
+	Skip uint
 }
 
 func (s *Settings) SetDefaults() {
 	s.File = defaultBoolPtr(s.File, false)
 	s.Line = defaultBoolPtr(s.Line, false)
 	s.Func = defaultBoolPtr(s.Func, false)
+	s.PathMode = defaultPathModePtr(s.PathMode, PathBase)
+	s.FuncMode = defaultFuncModePtr(s.FuncMode, FuncShort)
 }
 
 func (s *Settings) Copy() (settingsCopy Settings) {
 	settingsCopy.File = copyBoolPtr(s.File)
 	settingsCopy.Line = copyBoolPtr(s.Line)
 	settingsCopy.Func = copyBoolPtr(s.Func)
+	settingsCopy.PathMode = copyPathModePtr(s.PathMode)
+	settingsCopy.FuncMode = copyFuncModePtr(s.FuncMode)
+	settingsCopy.Skip = s.Skip
 	return settingsCopy
 }
 
@@ -30,35 +40,99 @@ func (s *Settings) OverrideWith(other Settings) {
 	s.File = overrideBoolPtr(s.File, other.File)
 	s.Line = overrideBoolPtr(s.Line, other.Line)
 	s.Func = overrideBoolPtr(s.Func, other.Func)
+	if other.PathMode != nil {
+		s.PathMode = copyPathModePtr(other.PathMode)
+	}
+	if other.FuncMode != nil {
+		s.FuncMode = copyFuncModePtr(other.FuncMode)
+	}
+	s.Skip += other.Skip
+}
+
+// Frame contains the caller information.
+type Frame struct {
+	// File is the full file path of the caller.
+	File string
+	// Line is the line number of the caller.
+	Line int
+	// Function is the full function name of the caller.
+	Function string
 }
 
-func Line(settings Settings) (s string) {
+// Get returns the caller frame of the log method call.
+// It returns an empty frame if no caller information is
+// enabled in the settings. Frames of functions marked with
+// MarkHelper are skipped and do not count towards the
+// settings skip.
+func Get(settings Settings) (frame Frame) {
 	if !*settings.File && !*settings.Line && !*settings.Func {
-		return ""
+		return frame
 	}
 
 	const depth = 3
-	pc, file, line, ok := runtime.Caller(depth)
-	if !ok {
-		return "error"
+
+	if !hasHelpers() {
+		pc, file, line, ok := runtime.Caller(depth + int(settings.Skip))
+		if !ok {
+			return frame
+		}
+
+		frame.File = file
+		frame.Line = line
+		details := runtime.FuncForPC(pc)
+		if details != nil {
+			frame.Function = details.Name()
+		}
+		return frame
+	}
+
+	const maxFrames = 32
+	pcs := make([]uintptr, maxFrames+settings.Skip)
+	// add 1 to skip runtime.Callers itself
+	n := runtime.Callers(depth+1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	skip := settings.Skip
+	for {
+		runtimeFrame, more := frames.Next()
+		frame = Frame{
+			File:     runtimeFrame.File,
+			Line:     runtimeFrame.Line,
+			Function: runtimeFrame.Function,
+		}
+		switch {
+		case !more:
+			return frame
+		case isHelper(runtimeFrame.Function):
+		case skip > 0:
+			skip--
+		default:
+			return frame
+		}
+	}
+}
+
+// Format formats the caller frame given depending
+// on the settings. Parts of the frame which are not set,
+// such as the line of a frame with only a function name,
+// are omitted. It returns the empty string if the frame is
+// empty or no caller information is enabled.
+func Format(settings Settings, frame Frame) (s string) {
+	if frame == (Frame{}) {
+		return ""
 	}
 
 	var fields []string
 
-	if *settings.File {
-		fields = append(fields, filepath.Base(file))
+	if *settings.File && frame.File != "" {
+		fields = append(fields, formatPath(*settings.PathMode, frame.File, frame.Function))
 	}
 
-	if *settings.Line {
-		fields = append(fields, "L"+fmt.Sprint(line))
+	if *settings.Line && frame.Line != 0 {
+		fields = append(fields, "L"+fmt.Sprint(frame.Line))
 	}
 
-	if *settings.Func {
-		details := runtime.FuncForPC(pc)
-		if details != nil {
-			funcName := strings.TrimLeft(filepath.Ext(details.Name()), ".")
-			fields = append(fields, funcName)
-		}
+	if *settings.Func && frame.Function != "" {
+		fields = append(fields, formatFunc(*settings.FuncMode, frame.Function))
 	}
 
 	return strings.Join(fields, ":")
@@ -32,21 +32,27 @@ func Test_Settings_SetDefaults(t *testing.T) {
 	}{
 		"empty settings": {
 			expectedSettings: Settings{
-				File: boolPtr(false),
-				Line: boolPtr(false),
-				Func: boolPtr(false),
+				File:     boolPtr(false),
+				Line:     boolPtr(false),
+				Func:     boolPtr(false),
+				PathMode: pathModePtr(PathBase),
+				FuncMode: funcModePtr(FuncShort),
 			},
 		},
 		"filled settings": {
 			initialSettings: Settings{
-				File: boolPtr(true),
-				Line: boolPtr(true),
-				Func: boolPtr(false),
+				File:     boolPtr(true),
+				Line:     boolPtr(true),
+				Func:     boolPtr(false),
+				PathMode: pathModePtr(PathModule),
+				FuncMode: funcModePtr(FuncPackage),
 			},
 			expectedSettings: Settings{
-				File: boolPtr(true),
-				Line: boolPtr(true),
-				Func: boolPtr(false),
+				File:     boolPtr(true),
+				Line:     boolPtr(true),
+				Func:     boolPtr(false),
+				PathMode: pathModePtr(PathModule),
+				FuncMode: funcModePtr(FuncPackage),
 			},
 		},
 	}
@@ -77,11 +83,13 @@ func Test_Settings_Copy(t *testing.T) {
 				File: boolPtr(true),
 				Line: boolPtr(true),
 				Func: boolPtr(false),
+				Skip: 1,
 			},
 			expectedSettings: Settings{
 				File: boolPtr(true),
 				Line: boolPtr(true),
 				Func: boolPtr(false),
+				Skip: 1,
 			},
 		},
 	}
@@ -141,16 +149,23 @@ func Test_Settings_OverrideWith(t *testing.T) {
 				File: boolPtr(true),
 				Line: boolPtr(true),
 				Func: boolPtr(false),
+				Skip: 1,
 			},
 			otherSettings: Settings{
-				File: boolPtr(false),
-				Line: boolPtr(false),
-				Func: boolPtr(true),
+				File:     boolPtr(false),
+				Line:     boolPtr(false),
+				Func:     boolPtr(true),
+				PathMode: pathModePtr(PathFull),
+				FuncMode: funcModePtr(FuncFull),
+				Skip:     2,
 			},
 			expectedSettings: Settings{
-				File: boolPtr(false),
-				Line: boolPtr(false),
-				Func: boolPtr(true),
+				File:     boolPtr(false),
+				Line:     boolPtr(false),
+				Func:     boolPtr(true),
+				PathMode: pathModePtr(PathFull),
+				FuncMode: funcModePtr(FuncFull),
+				Skip:     3,
 			},
 		},
 	}
@@ -173,7 +188,7 @@ func Test_Settings_OverrideWith(t *testing.T) {
 	}
 }
 
-func Test_Line(t *testing.T) {
+func Test_Get_Format(t *testing.T) {
 	t.Parallel()
 
 	// find line number of log call below
@@ -200,27 +215,43 @@ func Test_Line(t *testing.T) {
 	}{
 		"no show": {
 			settings: Settings{
-				File: boolPtr(false),
-				Line: boolPtr(false),
-				Func: boolPtr(false),
+				File:     boolPtr(false),
+				Line:     boolPtr(false),
+				Func:     boolPtr(false),
+				PathMode: pathModePtr(PathBase),
+				FuncMode: funcModePtr(FuncShort),
 			},
 		},
 		"show file line": {
 			settings: Settings{
-				File: boolPtr(true),
-				Line: boolPtr(true),
-				Func: boolPtr(false),
+				File:     boolPtr(true),
+				Line:     boolPtr(true),
+				Func:     boolPtr(false),
+				PathMode: pathModePtr(PathBase),
+				FuncMode: funcModePtr(FuncShort),
 			},
 			callerLine: fmt.Sprintf("caller_test.go:L%d", lineNumber),
 		},
 		"show all": {
 			settings: Settings{
-				File: boolPtr(true),
-				Line: boolPtr(true),
-				Func: boolPtr(true),
+				File:     boolPtr(true),
+				Line:     boolPtr(true),
+				Func:     boolPtr(true),
+				PathMode: pathModePtr(PathBase),
+				FuncMode: funcModePtr(FuncShort),
 			},
 			callerLine: fmt.Sprintf("caller_test.go:L%d:func1", lineNumber),
 		},
+		"show all module relative and package qualified": {
+			settings: Settings{
+				File:     boolPtr(true),
+				Line:     boolPtr(true),
+				Func:     boolPtr(true),
+				PathMode: pathModePtr(PathModule),
+				FuncMode: funcModePtr(FuncPackage),
+			},
+			callerLine: fmt.Sprintf("internal/caller/caller_test.go:L%d:caller.Test_Get_Format.func1", lineNumber),
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -231,7 +262,8 @@ func Test_Line(t *testing.T) {
 			var callerLine string
 			wrapFunc1 := func() { // Debug/Info calls
 				func() { // log function
-					callerLine = Line(testCase.settings)
+					frame := Get(testCase.settings)
+					callerLine = Format(testCase.settings, frame)
 				}()
 			}
 
@@ -241,3 +273,45 @@ func Test_Line(t *testing.T) {
 		})
 	}
 }
+
+func Test_Format_partialFrame(t *testing.T) {
+	t.Parallel()
+
+	settings := Settings{
+		File:     boolPtr(true),
+		Line:     boolPtr(true),
+		Func:     boolPtr(true),
+		PathMode: pathModePtr(PathFull),
+		FuncMode: funcModePtr(FuncFull),
+	}
+
+	testCases := map[string]struct {
+		frame      Frame
+		callerLine string
+	}{
+		"empty": {},
+		"function only": {
+			frame:      Frame{Function: "main.run"},
+			callerLine: "main.run",
+		},
+		"file only": {
+			frame:      Frame{File: "/app/main.go"},
+			callerLine: "/app/main.go",
+		},
+		"file and function": {
+			frame:      Frame{File: "/app/main.go", Function: "main.run"},
+			callerLine: "/app/main.go:main.run",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			callerLine := Format(settings, testCase.frame)
+
+			assert.Equal(t, testCase.callerLine, callerLine)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package caller
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	helpers      sync.Map //nolint:gochecknoglobals
+	helpersCount int32    //nolint:gochecknoglobals
+)
+
+// MarkHelper marks a function as a helper function, such that
+// it is skipped when determining the caller of a log call.
+// The skip argument is the number of stack frames to ascend,
+// with 0 identifying the caller of MarkHelper.
+func MarkHelper(skip int) {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return
+	}
+
+	frames := runtime.CallersFrames([]uintptr{pc})
+	frame, _ := frames.Next()
+	if frame.Function == "" {
+		return
+	}
+
+	_, loaded := helpers.LoadOrStore(frame.Function, struct{}{})
+	if !loaded {
+		atomic.AddInt32(&helpersCount, 1)
+	}
+}
+
+func isHelper(function string) bool {
+	_, ok := helpers.Load(function)
+	return ok
+}
+
+func hasHelpers() bool {
+	return atomic.LoadInt32(&helpersCount) > 0
+}
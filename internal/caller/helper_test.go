@@ -0,0 +1,99 @@
+package caller
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func currentLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+// testLogf mimics the logger internal log function.
+func testLogf(settings Settings) Frame { return Get(settings) }
+
+// testLogMethod mimics a logger method such as Info.
+func testLogMethod(settings Settings) Frame { return testLogf(settings) }
+
+func testWrapper(settings Settings) Frame { return testLogMethod(settings) }
+
+func testNestedWrapper(settings Settings) Frame { return testWrapper(settings) }
+
+func testHelper(settings Settings) Frame {
+	MarkHelper(0)
+	return testLogMethod(settings)
+}
+
+func testNestedHelper(settings Settings) Frame {
+	MarkHelper(0)
+	return testHelper(settings)
+}
+
+func Test_Get_skip(t *testing.T) {
+	t.Parallel()
+
+	newSettings := func(skip uint) Settings {
+		return Settings{
+			File:     boolPtr(true),
+			Line:     boolPtr(true),
+			Func:     boolPtr(true),
+			PathMode: pathModePtr(PathBase),
+			FuncMode: funcModePtr(FuncShort),
+			Skip:     skip,
+		}
+	}
+
+	const testFunction = "github.com/qdm12/log/internal/caller.Test_Get_skip"
+
+	t.Run("no wrapper", func(t *testing.T) {
+		t.Parallel()
+		frame, line := testLogMethod(newSettings(0)), currentLine()
+		assert.Equal(t, testFunction+".func2", frame.Function)
+		assert.Equal(t, line, frame.Line)
+	})
+
+	t.Run("wrapper without skip", func(t *testing.T) {
+		t.Parallel()
+		frame := testWrapper(newSettings(0))
+		assert.Equal(t, "github.com/qdm12/log/internal/caller.testWrapper", frame.Function)
+	})
+
+	t.Run("wrapper with skip", func(t *testing.T) {
+		t.Parallel()
+		frame, line := testWrapper(newSettings(1)), currentLine()
+		assert.Equal(t, testFunction+".func4", frame.Function)
+		assert.Equal(t, line, frame.Line)
+	})
+
+	t.Run("nested wrappers with skip", func(t *testing.T) {
+		t.Parallel()
+		frame, line := testNestedWrapper(newSettings(2)), currentLine()
+		assert.Equal(t, testFunction+".func5", frame.Function)
+		assert.Equal(t, line, frame.Line)
+	})
+
+	t.Run("helper", func(t *testing.T) {
+		t.Parallel()
+		frame, line := testHelper(newSettings(0)), currentLine()
+		assert.Equal(t, testFunction+".func6", frame.Function)
+		assert.Equal(t, line, frame.Line)
+	})
+
+	t.Run("nested helpers", func(t *testing.T) {
+		t.Parallel()
+		frame, line := testNestedHelper(newSettings(0)), currentLine()
+		assert.Equal(t, testFunction+".func7", frame.Function)
+		assert.Equal(t, line, frame.Line)
+	})
+
+	t.Run("helpers wrapped with skip", func(t *testing.T) {
+		t.Parallel()
+		wrapper := func() Frame { return testNestedHelper(newSettings(1)) }
+		frame, line := wrapper(), currentLine()
+		assert.Equal(t, testFunction+".func8", frame.Function)
+		assert.Equal(t, line, frame.Line)
+	})
+}
@@ -22,3 +22,33 @@ func overrideBoolPtr(existing, newValue *bool) *bool {
 	}
 	return copyBoolPtr(newValue)
 }
+
+func defaultPathModePtr(existing *PathMode, value PathMode) *PathMode {
+	if existing != nil {
+		return existing
+	}
+	return &value
+}
+
+func copyPathModePtr(mode *PathMode) *PathMode {
+	if mode == nil {
+		return nil
+	}
+	value := *mode
+	return &value
+}
+
+func defaultFuncModePtr(existing *FuncMode, value FuncMode) *FuncMode {
+	if existing != nil {
+		return existing
+	}
+	return &value
+}
+
+func copyFuncModePtr(mode *FuncMode) *FuncMode {
+	if mode == nil {
+		return nil
+	}
+	value := *mode
+	return &value
+}
@@ -0,0 +1,155 @@
+package caller
+
+import (
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// PathMode is the mode to display the caller file path.
+type PathMode uint8
+
+const (
+	// PathBase displays the file base name, such as handler.go.
+	PathBase PathMode = iota
+	// PathPackage displays the file base name prefixed with its
+	// parent directory, such as server/handler.go.
+	PathPackage
+	// PathModule displays the file path relative to its module
+	// root, such as internal/server/handler.go.
+	PathModule
+	// PathFull displays the full file path.
+	PathFull
+)
+
+// FuncMode is the mode to display the caller function name.
+type FuncMode uint8
+
+const (
+	// FuncShort displays the text after the last dot of the
+	// function name, such as ServeHTTP.
+	FuncShort FuncMode = iota
+	// FuncPackage displays the package qualified function
+	// name, such as server.(*Handler).ServeHTTP.
+	FuncPackage
+	// FuncFull displays the fully qualified function name, such
+	// as github.com/user/repo/server.(*Handler).ServeHTTP.
+	FuncFull
+)
+
+func formatPath(mode PathMode, file, function string) string {
+	switch mode {
+	case PathPackage:
+		return packageRelative(file)
+	case PathModule:
+		return moduleRelative(file, function)
+	case PathFull:
+		return file
+	default:
+		return filepath.Base(file)
+	}
+}
+
+func formatFunc(mode FuncMode, function string) string {
+	switch mode {
+	case FuncPackage:
+		return function[strings.LastIndex(function, "/")+1:]
+	case FuncFull:
+		return function
+	default:
+		return strings.TrimLeft(filepath.Ext(function), ".")
+	}
+}
+
+func packageRelative(file string) string {
+	// runtime file paths always use forward slashes
+	dir, base := path.Split(file)
+	parent := path.Base(dir)
+	if dir == "" || parent == "/" || parent == "." {
+		return base
+	}
+	return parent + "/" + base
+}
+
+// moduleRelative returns the file path relative to the root of
+// its module. It falls back on the path relative to GOPATH or
+// GOROOT, and then on the package relative path.
+func moduleRelative(file, function string) string {
+	// module cache path, such as /go/pkg/mod/github.com/x/y@v1.0.0/z/a.go
+	if i := strings.Index(file, "/pkg/mod/"); i >= 0 {
+		rest := file[i+len("/pkg/mod/"):]
+		if at := strings.Index(rest, "@"); at >= 0 {
+			if slash := strings.Index(rest[at:], "/"); slash >= 0 {
+				return rest[at+slash+1:]
+			}
+		}
+	}
+
+	packagePath := packagePathFromFunction(function)
+	for _, modulePath := range modulePaths() {
+		if packagePath == modulePath {
+			return path.Base(file)
+		} else if strings.HasPrefix(packagePath, modulePath+"/") {
+			return packagePath[len(modulePath)+1:] + "/" + path.Base(file)
+		}
+	}
+
+	goRoot := filepath.ToSlash(runtime.GOROOT())
+	if goRoot != "" && strings.HasPrefix(file, goRoot+"/src/") {
+		return strings.TrimPrefix(file, goRoot+"/src/")
+	}
+
+	if i := strings.Index(file, "/src/"); i >= 0 {
+		// GOPATH mode
+		return file[i+len("/src/"):]
+	}
+
+	return packageRelative(file)
+}
+
+// packagePathFromFunction returns the package import path of the
+// fully qualified function name given, for example it returns
+// github.com/x/y/server for github.com/x/y/server.(*Handler).ServeHTTP.
+// Note dots in the last element of the import path are escaped as
+// %2e by the linker, so the first dot after the last slash is always
+// the package path delimiter.
+func packagePathFromFunction(function string) string {
+	lastSlash := strings.LastIndex(function, "/")
+	packagePath := function
+	if dot := strings.Index(function[lastSlash+1:], "."); dot >= 0 {
+		packagePath = function[:lastSlash+1+dot]
+	}
+	return strings.ReplaceAll(packagePath, "%2e", ".")
+}
+
+var (
+	modulePathsOnce   sync.Once //nolint:gochecknoglobals
+	modulePathsCached []string  //nolint:gochecknoglobals
+)
+
+// modulePaths returns the paths of the modules of the program,
+// sorted from the longest to the shortest path.
+func modulePaths() []string {
+	modulePathsOnce.Do(func() {
+		buildInfo, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+
+		if buildInfo.Main.Path != "" {
+			modulePathsCached = append(modulePathsCached, buildInfo.Main.Path)
+		}
+		for _, dependency := range buildInfo.Deps {
+			modulePathsCached = append(modulePathsCached, dependency.Path)
+		}
+
+		sort.Slice(modulePathsCached, func(i, j int) bool {
+			return len(modulePathsCached[i]) > len(modulePathsCached[j])
+		})
+	})
+	return modulePathsCached
+}
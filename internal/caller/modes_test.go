@@ -0,0 +1,150 @@
+package caller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pathModePtr(mode PathMode) *PathMode { return &mode }
+
+func funcModePtr(mode FuncMode) *FuncMode { return &mode }
+
+func Test_formatPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		mode     PathMode
+		file     string
+		function string
+		path     string
+	}{
+		"base": {
+			mode: PathBase,
+			file: "/home/user/repo/server/handler.go",
+			path: "handler.go",
+		},
+		"package": {
+			mode: PathPackage,
+			file: "/home/user/repo/server/handler.go",
+			path: "server/handler.go",
+		},
+		"package at root": {
+			mode: PathPackage,
+			file: "/handler.go",
+			path: "handler.go",
+		},
+		"full": {
+			mode: PathFull,
+			file: "/home/user/repo/server/handler.go",
+			path: "/home/user/repo/server/handler.go",
+		},
+		"module from module cache": {
+			mode:     PathModule,
+			file:     "/go/pkg/mod/github.com/x/y@v1.2.3/server/handler.go",
+			function: "github.com/x/y/server.(*Handler).ServeHTTP",
+			path:     "server/handler.go",
+		},
+		"module from build info": {
+			mode:     PathModule,
+			file:     "/home/user/log/internal/caller/caller.go",
+			function: "github.com/qdm12/log/internal/caller.Get",
+			path:     "internal/caller/caller.go",
+		},
+		"module from GOPATH": {
+			mode:     PathModule,
+			file:     "/home/user/go/src/example.com/repo/server/handler.go",
+			function: "example.com/repo/server.(*Handler).ServeHTTP",
+			path:     "example.com/repo/server/handler.go",
+		},
+		"module fallback to package": {
+			mode:     PathModule,
+			file:     "/home/user/repo/server/handler.go",
+			function: "example.com/repo/server.(*Handler).ServeHTTP",
+			path:     "server/handler.go",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := formatPath(testCase.mode, testCase.file, testCase.function)
+
+			assert.Equal(t, testCase.path, path)
+		})
+	}
+}
+
+func Test_formatFunc(t *testing.T) {
+	t.Parallel()
+
+	const function = "github.com/x/y/server.(*Handler).ServeHTTP"
+
+	testCases := map[string]struct {
+		mode FuncMode
+		name string
+	}{
+		"short": {
+			mode: FuncShort,
+			name: "ServeHTTP",
+		},
+		"package": {
+			mode: FuncPackage,
+			name: "server.(*Handler).ServeHTTP",
+		},
+		"full": {
+			mode: FuncFull,
+			name: "github.com/x/y/server.(*Handler).ServeHTTP",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			name := formatFunc(testCase.mode, function)
+
+			assert.Equal(t, testCase.name, name)
+		})
+	}
+}
+
+func Test_packagePathFromFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		function    string
+		packagePath string
+	}{
+		"method": {
+			function:    "github.com/x/y/server.(*Handler).ServeHTTP",
+			packagePath: "github.com/x/y/server",
+		},
+		"dotted module": {
+			function:    "gopkg.in/yaml%2ev3.Unmarshal",
+			packagePath: "gopkg.in/yaml.v3",
+		},
+		"standard library": {
+			function:    "net/http.HandlerFunc.ServeHTTP",
+			packagePath: "net/http",
+		},
+		"main": {
+			function:    "main.main",
+			packagePath: "main",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			packagePath := packagePathFromFunction(testCase.function)
+
+			assert.Equal(t, testCase.packagePath, packagePath)
+		})
+	}
+}
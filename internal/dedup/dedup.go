@@ -0,0 +1,137 @@
+package dedup
+
+import (
+	"sync"
+	"time"
+)
+
+// Deduplicator suppresses consecutive identical records
+// within a time window. It is thread safe to use.
+type Deduplicator struct {
+	window time.Duration
+	mutex  sync.Mutex
+	last   *record
+	// timer ends the current series once its window elapsed,
+	// and is only armed once a record of the series is
+	// suppressed. It is created once and then reset.
+	timer *time.Timer
+	// deadline is the time at which the timer ends the
+	// current series.
+	deadline time.Time
+}
+
+// Key identifies a record to compare with the previous record.
+type Key struct {
+	Level     uint8
+	Component string
+	Message   string
+}
+
+// Summarize is called with the number of records suppressed
+// when a series of identical records ends. It returns a function
+// to call once the deduplicator is unlocked, which can be nil.
+type Summarize func(repeated uint) (after func())
+
+type record struct {
+	key       Key
+	start     time.Time
+	repeated  uint
+	summarize Summarize
+}
+
+// New creates a deduplicator for the given time window.
+func New(window time.Duration) *Deduplicator {
+	return &Deduplicator{
+		window: window,
+	}
+}
+
+// Check returns true if the record identified by key should
+// be written. If the record is identical to the previous record
+// and within the time window of the first of the series, it is
+// suppressed and false is returned. The summarize function given
+// is called when the series of identical records ends, either
+// because the window elapsed, a different record arrived or
+// Flush is called, and only if at least one record was suppressed.
+// The function returned by the summarize function of a series ended
+// by this record is returned as after, and must be called by the
+// caller once it releases its own locks.
+func (d *Deduplicator) Check(now time.Time, key Key,
+	summarize Summarize) (write bool, after func()) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.last != nil && d.last.key == key && now.Sub(d.last.start) < d.window {
+		d.last.repeated++
+		if d.last.repeated == 1 {
+			d.armTimer(d.window - now.Sub(d.last.start))
+		}
+		return false, nil
+	}
+
+	after = d.flush()
+
+	d.last = &record{
+		key:       key,
+		start:     now,
+		summarize: summarize,
+	}
+
+	return true, after
+}
+
+// armTimer arms the timer to end the current series after the
+// duration given. It must be called with the mutex locked.
+func (d *Deduplicator) armTimer(duration time.Duration) {
+	d.deadline = time.Now().Add(duration)
+	if d.timer == nil {
+		d.timer = time.AfterFunc(duration, d.expire)
+		return
+	}
+	d.timer.Reset(duration)
+}
+
+// expire ends the current series if its deadline is reached.
+// The deadline is checked since the timer may have fired for
+// a previous series while the mutex was locked to start a new
+// series and reset the timer.
+func (d *Deduplicator) expire() {
+	d.mutex.Lock()
+	if d.last == nil || d.last.repeated == 0 || time.Now().Before(d.deadline) {
+		d.mutex.Unlock()
+		return
+	}
+	after := d.flush()
+	d.mutex.Unlock()
+	callAfter(after)
+}
+
+// Flush ends the current series of identical records,
+// calling its summarize function if at least one record
+// was suppressed.
+func (d *Deduplicator) Flush() {
+	d.mutex.Lock()
+	after := d.flush()
+	d.mutex.Unlock()
+	callAfter(after)
+}
+
+func (d *Deduplicator) flush() (after func()) {
+	if d.last == nil {
+		return nil
+	}
+
+	if d.last.repeated > 0 {
+		// the timer is armed since records were suppressed
+		d.timer.Stop()
+		after = d.last.summarize(d.last.repeated)
+	}
+	d.last = nil
+	return after
+}
+
+func callAfter(after func()) {
+	if after != nil {
+		after()
+	}
+}
@@ -0,0 +1,194 @@
+package dedup
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// check calls Check and the function it returns, if any.
+func check(d *Deduplicator, now time.Time, key Key, summarize Summarize) (write bool) {
+	write, after := d.Check(now, key, summarize)
+	callAfter(after)
+	return write
+}
+
+func Test_Deduplicator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("different records", func(t *testing.T) {
+		t.Parallel()
+
+		d := New(time.Hour)
+		var summaries []uint
+		summarize := func(repeated uint) func() {
+			summaries = append(summaries, repeated)
+			return nil
+		}
+		now := time.Unix(0, 0)
+
+		assert.True(t, check(d, now, Key{Message: "a"}, summarize))
+		assert.True(t, check(d, now, Key{Message: "b"}, summarize))
+		assert.True(t, check(d, now, Key{Message: "b", Level: 1}, summarize))
+		assert.True(t, check(d, now, Key{Message: "b", Level: 1, Component: "c"}, summarize))
+		d.Flush()
+
+		assert.Empty(t, summaries)
+	})
+
+	t.Run("repeated then different record", func(t *testing.T) {
+		t.Parallel()
+
+		d := New(time.Hour)
+		var summaries []uint
+		summarize := func(repeated uint) func() {
+			summaries = append(summaries, repeated)
+			return nil
+		}
+		now := time.Unix(0, 0)
+
+		assert.True(t, check(d, now, Key{Message: "a"}, summarize))
+		assert.False(t, check(d, now, Key{Message: "a"}, summarize))
+		assert.False(t, check(d, now, Key{Message: "a"}, summarize))
+		assert.Empty(t, summaries)
+		assert.True(t, check(d, now, Key{Message: "b"}, summarize))
+		assert.Equal(t, []uint{2}, summaries)
+		d.Flush()
+		assert.Equal(t, []uint{2}, summaries)
+	})
+
+	t.Run("repeated after window", func(t *testing.T) {
+		t.Parallel()
+
+		d := New(time.Hour)
+		var summaries []uint
+		summarize := func(repeated uint) func() {
+			summaries = append(summaries, repeated)
+			return nil
+		}
+		now := time.Unix(0, 0)
+
+		assert.True(t, check(d, now, Key{Message: "a"}, summarize))
+		assert.False(t, check(d, now.Add(time.Minute), Key{Message: "a"}, summarize))
+		assert.True(t, check(d, now.Add(time.Hour), Key{Message: "a"}, summarize))
+		assert.Equal(t, []uint{1}, summaries)
+	})
+
+	t.Run("flush", func(t *testing.T) {
+		t.Parallel()
+
+		d := New(time.Hour)
+		var summaries []uint
+		summarize := func(repeated uint) func() {
+			summaries = append(summaries, repeated)
+			return nil
+		}
+		now := time.Unix(0, 0)
+
+		assert.True(t, check(d, now, Key{Message: "a"}, summarize))
+		assert.False(t, check(d, now, Key{Message: "a"}, summarize))
+		d.Flush()
+		assert.Equal(t, []uint{1}, summaries)
+		assert.True(t, check(d, now, Key{Message: "a"}, summarize))
+	})
+
+	t.Run("window timer", func(t *testing.T) {
+		t.Parallel()
+
+		const window = 10 * time.Millisecond
+		d := New(window)
+		summarized := make(chan uint)
+		summarize := func(repeated uint) func() {
+			summarized <- repeated
+			return nil
+		}
+		now := time.Now()
+
+		assert.True(t, check(d, now, Key{Message: "a"}, summarize))
+		assert.False(t, check(d, now, Key{Message: "a"}, summarize))
+		assert.False(t, check(d, now, Key{Message: "a"}, summarize))
+
+		select {
+		case repeated := <-summarized:
+			assert.Equal(t, uint(2), repeated)
+		case <-time.After(time.Second):
+			t.Fatal("summary not emitted after window")
+		}
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		t.Parallel()
+
+		d := New(time.Hour)
+		var mutex sync.Mutex
+		var total uint
+		summarize := func(repeated uint) func() {
+			mutex.Lock()
+			total += repeated
+			mutex.Unlock()
+			return nil
+		}
+
+		const parallelism = 10
+		var wg sync.WaitGroup
+		wg.Add(parallelism)
+		for i := 0; i < parallelism; i++ {
+			go func() {
+				defer wg.Done()
+				check(d, time.Now(), Key{Message: "a"}, summarize)
+			}()
+		}
+		wg.Wait()
+		d.Flush()
+
+		assert.Equal(t, uint(parallelism-1), total)
+	})
+}
+
+func Test_Deduplicator_timer(t *testing.T) {
+	t.Parallel()
+
+	d := New(time.Hour)
+	summarize := func(repeated uint) func() { return nil }
+	now := time.Unix(0, 0)
+
+	// no timer is needed while no record is suppressed
+	check(d, now, Key{Message: "a"}, summarize)
+	check(d, now, Key{Message: "b"}, summarize)
+	assert.Nil(t, d.timer)
+
+	// a single timer is reused across series
+	check(d, now, Key{Message: "b"}, summarize)
+	timer := d.timer
+	assert.NotNil(t, timer)
+	check(d, now, Key{Message: "c"}, summarize)
+	check(d, now, Key{Message: "c"}, summarize)
+	assert.Same(t, timer, d.timer)
+	d.Flush()
+}
+
+func Test_Deduplicator_after(t *testing.T) {
+	t.Parallel()
+
+	d := New(time.Hour)
+	var calls []string
+	summarize := func(repeated uint) func() {
+		calls = append(calls, "summarize")
+		return func() {
+			// the deduplicator is unlocked
+			d.Flush()
+			calls = append(calls, "after")
+		}
+	}
+	now := time.Unix(0, 0)
+
+	check(d, now, Key{Message: "a"}, summarize)
+	check(d, now, Key{Message: "a"}, summarize)
+	write, after := d.Check(now, Key{Message: "b"}, summarize)
+	assert.True(t, write)
+	assert.Equal(t, []string{"summarize"}, calls)
+	after()
+	assert.Equal(t, []string{"summarize", "after"}, calls)
+}
@@ -0,0 +1,102 @@
+package sampling
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sampler limits the number of records logged per message
+// template and level. It is thread safe to use.
+type Sampler struct {
+	first      uint64
+	thereafter uint64
+	interval   time.Duration
+
+	// dropped is accessed atomically.
+	dropped uint64
+
+	mutex    sync.Mutex
+	counters map[key]*counter
+	// lastSweep is the time counters with an expired
+	// window were last removed.
+	lastSweep time.Time
+}
+
+type key struct {
+	level    uint8
+	template string
+}
+
+type counter struct {
+	windowStart time.Time
+	count       uint64
+}
+
+// New creates a sampler letting the first records through
+// for each message template and level per interval, and then
+// only every thereafter-th record. If thereafter is 0, all
+// records after the first ones are dropped until the end
+// of the interval.
+func New(first, thereafter uint, interval time.Duration) *Sampler {
+	return &Sampler{
+		first:      uint64(first),
+		thereafter: uint64(thereafter),
+		interval:   interval,
+		counters:   make(map[key]*counter),
+	}
+}
+
+// Sample returns true if the record with the given level
+// and message template should be logged at the given time.
+func (s *Sampler) Sample(now time.Time, level uint8, template string) (keep bool) {
+	k := key{level: level, template: template}
+
+	s.mutex.Lock()
+	if now.Sub(s.lastSweep) >= s.interval {
+		s.sweep(now)
+	}
+	c, ok := s.counters[k]
+	if !ok {
+		c = &counter{windowStart: now}
+		s.counters[k] = c
+	} else if now.Sub(c.windowStart) >= s.interval {
+		c.windowStart = now
+		c.count = 0
+	}
+	c.count++
+	count := c.count
+	s.mutex.Unlock()
+
+	switch {
+	case count <= s.first:
+		keep = true
+	case s.thereafter == 0:
+		keep = false
+	default:
+		keep = (count-s.first)%s.thereafter == 0
+	}
+
+	if !keep {
+		atomic.AddUint64(&s.dropped, 1)
+	}
+	return keep
+}
+
+// sweep removes the counters with an expired window, such that
+// counters of templates no longer logged do not accumulate.
+// It must be called with the mutex locked.
+func (s *Sampler) sweep(now time.Time) {
+	for k, c := range s.counters {
+		if now.Sub(c.windowStart) >= s.interval {
+			delete(s.counters, k)
+		}
+	}
+	s.lastSweep = now
+}
+
+// Dropped returns the total number of records dropped
+// by the sampler.
+func (s *Sampler) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
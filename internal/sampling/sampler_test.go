@@ -0,0 +1,117 @@
+package sampling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Sampler_Sample(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		offset   time.Duration
+		level    uint8
+		template string
+		keep     bool
+	}
+
+	testCases := map[string]struct {
+		first      uint
+		thereafter uint
+		interval   time.Duration
+		calls      []call
+		dropped    uint64
+	}{
+		"first only": {
+			first:    2,
+			interval: time.Second,
+			calls: []call{
+				{template: "a", keep: true},
+				{template: "a", keep: true},
+				{template: "a", keep: false},
+				{template: "a", keep: false},
+			},
+			dropped: 2,
+		},
+		"first and thereafter": {
+			first:      1,
+			thereafter: 2,
+			interval:   time.Second,
+			calls: []call{
+				{template: "a", keep: true},
+				{template: "a", keep: false},
+				{template: "a", keep: true},
+				{template: "a", keep: false},
+				{template: "a", keep: true},
+			},
+			dropped: 2,
+		},
+		"distinct templates and levels": {
+			first:    1,
+			interval: time.Second,
+			calls: []call{
+				{template: "a", keep: true},
+				{template: "b", keep: true},
+				{template: "a", level: 1, keep: true},
+				{template: "a", keep: false},
+			},
+			dropped: 1,
+		},
+		"interval reset": {
+			first:    1,
+			interval: time.Second,
+			calls: []call{
+				{template: "a", keep: true},
+				{offset: 500 * time.Millisecond, template: "a", keep: false},
+				{offset: time.Second, template: "a", keep: true},
+				{offset: 1500 * time.Millisecond, template: "a", keep: false},
+			},
+			dropped: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sampler := New(testCase.first, testCase.thereafter, testCase.interval)
+			start := time.Unix(0, 0)
+
+			for i, call := range testCase.calls {
+				keep := sampler.Sample(start.Add(call.offset), call.level, call.template)
+				assert.Equal(t, call.keep, keep, "call %d", i)
+			}
+
+			assert.Equal(t, testCase.dropped, sampler.Dropped())
+		})
+	}
+}
+
+func Test_Sampler_Sample_eviction(t *testing.T) {
+	t.Parallel()
+
+	sampler := New(1, 0, time.Second)
+	start := time.Unix(0, 0)
+
+	sampler.Sample(start, 0, "a")
+	sampler.Sample(start.Add(500*time.Millisecond), 0, "b")
+	assert.Len(t, sampler.counters, 2)
+
+	// the window of "a" is expired at the next sweep
+	sampler.Sample(start.Add(1200*time.Millisecond), 0, "c")
+	assert.Len(t, sampler.counters, 2)
+	assert.NotContains(t, sampler.counters, key{template: "a"})
+
+	// no sweep until an interval elapsed since the last sweep
+	sampler.Sample(start.Add(1600*time.Millisecond), 0, "d")
+	assert.Len(t, sampler.counters, 3)
+
+	// windows of "b", "c" and "d" are expired
+	sampler.Sample(start.Add(3*time.Second), 0, "e")
+	assert.Equal(t, map[key]*counter{
+		{template: "e"}: {windowStart: start.Add(3 * time.Second), count: 1},
+	}, sampler.counters)
+}
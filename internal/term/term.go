@@ -0,0 +1,88 @@
+// Package term detects terminal properties of writers.
+package term
+
+import (
+	"io"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Width returns the width in columns of the terminal the writer
+// writes to, or 0 if the writer is not a terminal.
+// Widths are cached per writer and refreshed every second, to
+// follow terminal resizes without querying the terminal for
+// every call.
+func Width(writer io.Writer) int {
+	return widthCache.width(writer, time.Now())
+}
+
+//nolint:gochecknoglobals
+var widthCache = newCache(time.Second, query)
+
+type cache struct {
+	refreshPeriod time.Duration
+	query         func(conn syscall.Conn) (width int)
+	mutex         sync.Mutex
+	entries       map[syscall.Conn]entry
+}
+
+type entry struct {
+	width     int
+	queriedAt time.Time
+}
+
+func newCache(refreshPeriod time.Duration,
+	query func(conn syscall.Conn) (width int)) *cache {
+	return &cache{
+		refreshPeriod: refreshPeriod,
+		query:         query,
+		entries:       make(map[syscall.Conn]entry),
+	}
+}
+
+// maxEntries is the maximum number of writers cached, after
+// which the cache is emptied, in case many files are used.
+const maxEntries = 1024
+
+func (c *cache) width(writer io.Writer, now time.Time) int {
+	conn, ok := writer.(syscall.Conn)
+	if !ok || !reflect.TypeOf(conn).Comparable() {
+		return 0
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cached, ok := c.entries[conn]
+	if ok && now.Sub(cached.queriedAt) < c.refreshPeriod {
+		return cached.width
+	}
+
+	if !ok && len(c.entries) >= maxEntries {
+		c.entries = make(map[syscall.Conn]entry)
+	}
+
+	cached = entry{width: c.query(conn), queriedAt: now}
+	c.entries[conn] = cached
+	return cached.width
+}
+
+// query queries the terminal width of the connection, such as
+// an *os.File, using its raw file descriptor. The file descriptor
+// is accessed with SyscallConn, since calling the Fd method of
+// an *os.File would set it to blocking mode.
+func query(conn syscall.Conn) (columns int) {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return 0
+	}
+	err = rawConn.Control(func(fd uintptr) {
+		columns = width(fd)
+	})
+	if err != nil {
+		return 0
+	}
+	return columns
+}
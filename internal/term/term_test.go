@@ -0,0 +1,58 @@
+package term
+
+import (
+	"bytes"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Width(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not a file", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, 0, Width(bytes.NewBuffer(nil)))
+	})
+
+	t.Run("pipe", func(t *testing.T) {
+		t.Parallel()
+		reader, writer, err := os.Pipe()
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = reader.Close()
+			_ = writer.Close()
+		})
+		assert.Equal(t, 0, Width(writer))
+	})
+}
+
+func Test_cache_width(t *testing.T) {
+	t.Parallel()
+
+	queries := 0
+	query := func(conn syscall.Conn) int {
+		queries++
+		return 80 + queries
+	}
+	c := newCache(time.Second, query)
+	_, writer, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = writer.Close() })
+	now := time.Unix(0, 0)
+
+	assert.Equal(t, 0, c.width(bytes.NewBuffer(nil), now))
+	assert.Equal(t, 0, queries)
+
+	assert.Equal(t, 81, c.width(writer, now))
+	assert.Equal(t, 81, c.width(writer, now.Add(999*time.Millisecond)))
+	assert.Equal(t, 1, queries)
+
+	// the width is refreshed after the refresh period
+	assert.Equal(t, 82, c.width(writer, now.Add(time.Second)))
+	assert.Equal(t, 2, queries)
+}
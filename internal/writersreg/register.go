@@ -30,6 +30,8 @@ func (r *Registry) registerWriter(writer io.Writer) (mutex *sync.Mutex) {
 
 	writerAddress := fmt.Sprintf("%p", writer)
 
+	r.writerAddressToReferences[writerAddress]++
+
 	mutex, ok := r.writerAddressToMutex[writerAddress]
 	if ok {
 		// writer already registered
@@ -40,3 +42,31 @@ func (r *Registry) registerWriter(writer io.Writer) (mutex *sync.Mutex) {
 	r.writerAddressToMutex[writerAddress] = mutex
 	return mutex
 }
+
+// ReleaseWriters releases writers previously registered, and
+// removes a writer from the registry once it is released as
+// many times as it was registered.
+func (r *Registry) ReleaseWriters(writers []io.Writer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, writer := range writers {
+		if writer == nil || writer == io.Discard {
+			continue
+		}
+
+		writerAddress := fmt.Sprintf("%p", writer)
+		references, ok := r.writerAddressToReferences[writerAddress]
+		if !ok {
+			continue
+		}
+
+		if references > 1 {
+			r.writerAddressToReferences[writerAddress] = references - 1
+			continue
+		}
+
+		delete(r.writerAddressToReferences, writerAddress)
+		delete(r.writerAddressToMutex, writerAddress)
+	}
+}
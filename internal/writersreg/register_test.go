@@ -78,6 +78,9 @@ func Test_Registry_registerWriter(t *testing.T) {
 			writerAddressToMutex: map[string]*sync.Mutex{
 				writerAddress: existingMutex,
 			},
+			writerAddressToReferences: map[string]uint{
+				writerAddress: 1,
+			},
 		}
 
 		mutex := registry.registerWriter(writer)
@@ -88,6 +91,9 @@ func Test_Registry_registerWriter(t *testing.T) {
 			writerAddressToMutex: map[string]*sync.Mutex{
 				writerAddress: existingMutex,
 			},
+			writerAddressToReferences: map[string]uint{
+				writerAddress: 2,
+			},
 		}
 		assert.Equal(t, expectedRegistry, registry)
 	})
@@ -99,7 +105,8 @@ func Test_Registry_registerWriter(t *testing.T) {
 		writerAddress := fmt.Sprintf("%p", writer)
 
 		registry := &Registry{
-			writerAddressToMutex: map[string]*sync.Mutex{},
+			writerAddressToMutex:      map[string]*sync.Mutex{},
+			writerAddressToReferences: map[string]uint{},
 		}
 
 		mutex := registry.registerWriter(writer)
@@ -111,7 +118,35 @@ func Test_Registry_registerWriter(t *testing.T) {
 			writerAddressToMutex: map[string]*sync.Mutex{
 				writerAddress: registryMutex,
 			},
+			writerAddressToReferences: map[string]uint{
+				writerAddress: 1,
+			},
 		}
 		assert.Equal(t, expectedRegistry, registry)
 	})
 }
+
+func Test_Registry_ReleaseWriters(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	writerA := bytes.NewBuffer(nil)
+	writerB := bytes.NewBuffer(nil)
+
+	mutexesA := registry.RegisterWriters([]io.Writer{writerA, io.Discard})
+	mutexesB := registry.RegisterWriters([]io.Writer{writerA, writerB})
+	assertMutexesEqualAddress(t, mutexesA[0], mutexesB[0])
+
+	registry.ReleaseWriters([]io.Writer{writerA, writerB, io.Discard})
+	assert.Len(t, registry.writerAddressToMutex, 1)
+	mutexesC := registry.RegisterWriters([]io.Writer{writerA})
+	assertMutexesEqualAddress(t, mutexesA[0], mutexesC[0])
+
+	registry.ReleaseWriters([]io.Writer{writerA})
+	registry.ReleaseWriters([]io.Writer{writerA})
+	assert.Empty(t, registry.writerAddressToMutex)
+	assert.Empty(t, registry.writerAddressToReferences)
+
+	// releasing a writer not registered is a no-op
+	registry.ReleaseWriters([]io.Writer{writerB})
+}
@@ -7,11 +7,15 @@ import (
 func NewRegistry() *Registry {
 	const initialWriterCapacity = 1
 	return &Registry{
-		writerAddressToMutex: make(map[string]*sync.Mutex, initialWriterCapacity),
+		writerAddressToMutex:      make(map[string]*sync.Mutex, initialWriterCapacity),
+		writerAddressToReferences: make(map[string]uint, initialWriterCapacity),
 	}
 }
 
 type Registry struct {
 	mutex                sync.RWMutex
 	writerAddressToMutex map[string]*sync.Mutex
+	// writerAddressToReferences is the number of times each
+	// writer is registered and not yet released.
+	writerAddressToReferences map[string]uint
 }
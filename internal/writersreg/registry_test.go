@@ -13,7 +13,8 @@ func Test_NewRegistry(t *testing.T) {
 	registry := NewRegistry()
 
 	expectedRegistry := &Registry{
-		writerAddressToMutex: make(map[string]*sync.Mutex, 1),
+		writerAddressToMutex:      make(map[string]*sync.Mutex, 1),
+		writerAddressToReferences: make(map[string]uint, 1),
 	}
 
 	assert.Equal(t, expectedRegistry, registry)
@@ -0,0 +1,108 @@
+package journald
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/log"
+)
+
+// LevelToPriority returns the journald priority, which is the
+// syslog severity, corresponding to the log level given.
+func LevelToPriority(level log.Level) int {
+	const (
+		priorityError   = 3
+		priorityWarning = 4
+		priorityNotice  = 5
+		priorityInfo    = 6
+		priorityDebug   = 7
+	)
+
+	switch level {
+	case log.LevelError:
+		return priorityError
+	case log.LevelWarn:
+		return priorityWarning
+	case log.LevelInfo:
+		return priorityInfo
+	case log.LevelDebug:
+		return priorityDebug
+	default:
+		return priorityNotice
+	}
+}
+
+// encode encodes the record in the journald native protocol
+// format, where each field is written as `KEY=value\n`, or as
+// `KEY\n` followed by the value length as a little endian 64 bits
+// integer, the value and `\n` if the value contains a new line.
+func encode(settings Settings, record log.Record) []byte {
+	buffer := bytes.NewBuffer(nil)
+
+	writeField(buffer, "MESSAGE", record.Message)
+	writeField(buffer, "PRIORITY", strconv.Itoa(LevelToPriority(record.Level)))
+
+	identifier := settings.Identifier
+	if record.Component != "" {
+		if settings.ComponentField == "SYSLOG_IDENTIFIER" {
+			identifier = record.Component
+		} else {
+			writeField(buffer, settings.ComponentField, record.Component)
+		}
+	}
+	writeField(buffer, "SYSLOG_IDENTIFIER", identifier)
+
+	if record.Caller.File != "" {
+		writeField(buffer, "CODE_FILE", record.Caller.File)
+		writeField(buffer, "CODE_LINE", strconv.Itoa(record.Caller.Line))
+		if record.Caller.Function != "" {
+			writeField(buffer, "CODE_FUNC", record.Caller.Function)
+		}
+	}
+
+	for _, field := range record.Fields {
+		writeField(buffer, fieldName(field.Key), fmt.Sprint(field.Value))
+	}
+
+	return buffer.Bytes()
+}
+
+func writeField(buffer *bytes.Buffer, name, value string) {
+	buffer.WriteString(name)
+	if !strings.ContainsRune(value, '\n') {
+		buffer.WriteByte('=')
+		buffer.WriteString(value)
+		buffer.WriteByte('\n')
+		return
+	}
+
+	buffer.WriteByte('\n')
+	_ = binary.Write(buffer, binary.LittleEndian, uint64(len(value)))
+	buffer.WriteString(value)
+	buffer.WriteByte('\n')
+}
+
+// fieldName returns a valid journal field name, which only
+// contains upper case letters, digits and underscores, and
+// does not start with an underscore or a digit.
+func fieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+
+	name = strings.TrimLeft(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "FIELD_" + name
+	}
+	return name
+}
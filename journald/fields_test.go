@@ -0,0 +1,90 @@
+package journald
+
+import (
+	"testing"
+
+	"github.com/qdm12/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_encode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		settings Settings
+		record   log.Record
+		encoded  string
+	}{
+		"minimal record": {
+			settings: Settings{
+				Identifier:     "app",
+				ComponentField: "SYSLOG_IDENTIFIER",
+			},
+			record: log.Record{
+				Level:   log.LevelInfo,
+				Message: "message",
+			},
+			encoded: "MESSAGE=message\nPRIORITY=6\nSYSLOG_IDENTIFIER=app\n",
+		},
+		"component as identifier": {
+			settings: Settings{
+				Identifier:     "app",
+				ComponentField: "SYSLOG_IDENTIFIER",
+			},
+			record: log.Record{
+				Level:     log.LevelError,
+				Component: "http",
+				Message:   "message",
+			},
+			encoded: "MESSAGE=message\nPRIORITY=3\nSYSLOG_IDENTIFIER=http\n",
+		},
+		"full record": {
+			settings: Settings{
+				Identifier:     "app",
+				ComponentField: "COMPONENT",
+			},
+			record: log.Record{
+				Level:     log.LevelWarn,
+				Component: "http",
+				Message:   "multi\nline",
+				Caller: log.Caller{
+					File:     "/a/main.go",
+					Line:     12,
+					Function: "main.main",
+				},
+				Fields: []log.Field{{Key: "user-id", Value: 1}},
+			},
+			encoded: "MESSAGE\n\x0a\x00\x00\x00\x00\x00\x00\x00multi\nline\n" +
+				"PRIORITY=4\nCOMPONENT=http\nSYSLOG_IDENTIFIER=app\n" +
+				"CODE_FILE=/a/main.go\nCODE_LINE=12\nCODE_FUNC=main.main\n" +
+				"USER_ID=1\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded := encode(testCase.settings, testCase.record)
+
+			assert.Equal(t, testCase.encoded, string(encoded))
+		})
+	}
+}
+
+func Test_fieldName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"key":      "KEY",
+		"user-id":  "USER_ID",
+		"_private": "PRIVATE",
+		"1st":      "FIELD_1ST",
+		"":         "FIELD_",
+	}
+
+	for key, expected := range testCases {
+		assert.Equal(t, expected, fieldName(key), key)
+	}
+}
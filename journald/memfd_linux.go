@@ -0,0 +1,59 @@
+//go:build linux
+// +build linux
+
+package journald
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// sendWithMemfd writes the data to a sealed memory file and
+// sends its file descriptor to journald, as journald does for
+// payloads too large to fit in a single datagram.
+func sendWithMemfd(conn *net.UnixConn, data []byte) (err error) {
+	fd, err := unix.MemfdCreate("journald", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return fmt.Errorf("creating memory file: %w", err)
+	}
+	file := os.NewFile(uintptr(fd), "journald")
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return fmt.Errorf("writing to memory file: %w", err)
+	}
+
+	const seals = unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE | unix.F_SEAL_SEAL
+	_, err = unix.FcntlInt(file.Fd(), unix.F_ADD_SEALS, seals)
+	if err != nil {
+		return fmt.Errorf("sealing memory file: %w", err)
+	}
+
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return fmt.Errorf("getting raw connection: %w", err)
+	}
+
+	// WriteMsgUnix cannot be used on a connected datagram socket,
+	// so the file descriptor is sent with sendmsg directly.
+	rights := syscall.UnixRights(int(file.Fd()))
+	var sendErr error
+	err = rawConn.Write(func(socketFd uintptr) (done bool) {
+		sendErr = syscall.Sendmsg(int(socketFd), nil, rights, nil, 0)
+		return !errors.Is(sendErr, syscall.EAGAIN)
+	})
+	if err == nil {
+		err = sendErr
+	}
+	if err != nil {
+		return fmt.Errorf("sending memory file descriptor: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+// +build !linux
+
+package journald
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+var ErrMemfdNotSupported = errors.New("memfd is only supported on Linux")
+
+func sendWithMemfd(*net.UnixConn, []byte) (err error) {
+	return fmt.Errorf("%w", ErrMemfdNotSupported)
+}
@@ -0,0 +1,40 @@
+package journald
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Settings contains settings for the journald writer.
+type Settings struct {
+	// Path is the path to the journald native protocol
+	// unix datagram socket.
+	// It defaults to "/run/systemd/journal/socket".
+	Path string
+	// Identifier is the value of the SYSLOG_IDENTIFIER
+	// field used when the record has no component, or
+	// when the component is written to another field.
+	// It defaults to the base name of the program.
+	Identifier string
+	// ComponentField is the journal field name to write
+	// the record component to. Field names are converted
+	// to upper case and characters other than letters, digits
+	// and underscores are replaced by underscores.
+	// It defaults to "SYSLOG_IDENTIFIER".
+	ComponentField string
+}
+
+func (s *Settings) setDefaults() {
+	if s.Path == "" {
+		s.Path = "/run/systemd/journal/socket"
+	}
+
+	if s.Identifier == "" {
+		s.Identifier = filepath.Base(os.Args[0])
+	}
+
+	if s.ComponentField == "" {
+		s.ComponentField = "SYSLOG_IDENTIFIER"
+	}
+	s.ComponentField = fieldName(s.ComponentField)
+}
@@ -0,0 +1,93 @@
+package journald
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/qdm12/log"
+)
+
+var _ log.RecordWriter = (*Writer)(nil)
+
+// Writer is a journald writer using the journal native protocol,
+// which can be given to a logger with the log.SetWriters or
+// log.AddWriters options. It is thread safe to use.
+type Writer struct {
+	settings Settings
+	mutex    sync.Mutex
+	conn     *net.UnixConn
+}
+
+// New creates a new journald writer connected to the
+// journald socket.
+func New(settings Settings) (writer *Writer, err error) {
+	settings.setDefaults()
+
+	address := &net.UnixAddr{Name: settings.Path, Net: "unixgram"}
+	conn, err := net.DialUnix("unixgram", nil, address)
+	if err != nil {
+		return nil, fmt.Errorf("dialing journald socket: %w", err)
+	}
+
+	return &Writer{
+		settings: settings,
+		conn:     conn,
+	}, nil
+}
+
+// WriteRecord encodes and sends the record to journald.
+// If the encoded record is too large to be sent as a single
+// datagram, it is written to a sealed memory file whose file
+// descriptor is sent to journald instead.
+func (w *Writer) WriteRecord(record log.Record) (err error) {
+	data := encode(w.settings, record)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	_, err = w.conn.Write(data)
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return fmt.Errorf("writing to journald socket: %w", err)
+	}
+
+	err = sendWithMemfd(w.conn, data)
+	if err != nil {
+		return fmt.Errorf("sending large record to journald: %w", err)
+	}
+	return nil
+}
+
+// Write sends the data given as a message at the
+// info level to journald, with any trailing new line removed.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	record := log.Record{
+		Level:   log.LevelInfo,
+		Time:    time.Now(),
+		Message: strings.TrimSuffix(string(p), "\n"),
+	}
+
+	err = w.WriteRecord(record)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection to the journald socket.
+func (w *Writer) Close() (err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	err = w.conn.Close()
+	if err != nil {
+		return fmt.Errorf("closing journald socket: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+//go:build linux
+// +build linux
+
+package journald
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/qdm12/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listen(t *testing.T) (conn *net.UnixConn, path string) {
+	t.Helper()
+
+	path = filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	return conn, path
+}
+
+func Test_Writer(t *testing.T) {
+	t.Parallel()
+
+	listener, path := listen(t)
+
+	writer, err := New(Settings{Path: path, Identifier: "app"})
+	require.NoError(t, err)
+	defer writer.Close()
+
+	logger := log.New(log.SetWriters(writer), log.SetComponent("component"))
+	logger.Warn("some message")
+
+	buffer := make([]byte, 1024)
+	n, err := listener.Read(buffer)
+	require.NoError(t, err)
+
+	expected := "MESSAGE=some message\nPRIORITY=4\nSYSLOG_IDENTIFIER=component\n"
+	assert.Equal(t, expected, string(buffer[:n]))
+}
+
+func Test_Writer_memfd(t *testing.T) {
+	t.Parallel()
+
+	listener, path := listen(t)
+
+	writer, err := New(Settings{Path: path, Identifier: "app"})
+	require.NoError(t, err)
+	defer writer.Close()
+
+	const messageSize = 4 * 1024 * 1024
+	message := strings.Repeat("a", messageSize)
+	_, err = writer.Write([]byte(message))
+	require.NoError(t, err)
+
+	buffer := make([]byte, 1024)
+	oob := make([]byte, syscall.CmsgSpace(4)) //nolint:gomnd
+	n, oobn, _, _, err := listener.ReadMsgUnix(buffer, oob)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	messages, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	fds, err := syscall.ParseUnixRights(&messages[0])
+	require.NoError(t, err)
+	require.Len(t, fds, 1)
+
+	file := os.NewFile(uintptr(fds[0]), "memfd")
+	defer file.Close()
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+
+	expected := "MESSAGE=" + message + "\nPRIORITY=6\nSYSLOG_IDENTIFIER=app\n"
+	assert.Equal(t, expected, string(data))
+}
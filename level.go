@@ -22,6 +22,9 @@ const (
 	LevelDebug
 )
 
+//nolint:gochecknoglobals
+var allLevels = [...]Level{LevelError, LevelWarn, LevelInfo, LevelDebug}
+
 func (level Level) String() (s string) {
 	switch level {
 	case LevelError:
@@ -38,23 +41,25 @@ func (level Level) String() (s string) {
 }
 
 // ColoredString returns the corresponding colored
-// string for the level.
+// string for the level, using the default theme colors.
 func (level Level) ColoredString() (s string) {
-	attribute := color.Reset
+	c := color.New(level.colorAttribute())
+	return c.Sprint(level.String())
+}
 
+func (level Level) colorAttribute() color.Attribute {
 	switch level {
 	case LevelDebug:
-		attribute = color.FgHiBlue
+		return color.FgHiBlue
 	case LevelInfo:
-		attribute = color.FgCyan
+		return color.FgCyan
 	case LevelWarn:
-		attribute = color.FgYellow
+		return color.FgYellow
 	case LevelError:
-		attribute = color.FgHiRed
+		return color.FgHiRed
+	default:
+		return color.Reset
 	}
-
-	c := color.New(attribute)
-	return c.Sprint(level.String())
 }
 
 var (
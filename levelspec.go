@@ -0,0 +1,159 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+)
+
+// LevelSpec is a specification of levels per component, parsed
+// from a string such as "info,http=debug,db/*=warn". It can be
+// updated at runtime, and all loggers using it are affected.
+// It is thread safe to use.
+type LevelSpec struct {
+	mutex        sync.RWMutex
+	spec         string
+	defaultLevel *Level
+	rules        []levelRule
+	// cache maps a component and separator to its resolved level.
+	cache map[string]resolvedLevel
+}
+
+type levelRule struct {
+	pattern string
+	level   Level
+}
+
+type resolvedLevel struct {
+	level Level
+	ok    bool
+}
+
+var (
+	ErrLevelSpecEntryNotValid = errors.New("level spec entry is not valid")
+	ErrLevelSpecPatternBad    = errors.New("level spec pattern is malformed")
+)
+
+// ParseLevelSpec parses a level specification string, which is a
+// comma separated list of entries. Each entry is either a level,
+// which is the level for all components not matched by another
+// entry, or a component pattern and a level separated by an equal
+// sign, such as "http=debug". Patterns use the syntax of path.Match,
+// for example "db*" or "api/*", and also match components nested
+// under a matching component path, for example "api" matches
+// "api/auth".
+func ParseLevelSpec(spec string) (levelSpec *LevelSpec, err error) {
+	levelSpec = &LevelSpec{}
+	err = levelSpec.Update(spec)
+	if err != nil {
+		return nil, err
+	}
+	return levelSpec, nil
+}
+
+// Update updates the level specification with the specification
+// string given, and returns an error if it is not valid, in which
+// case the level specification is left unchanged. All loggers using
+// this level specification use the updated levels immediately.
+func (s *LevelSpec) Update(spec string) (err error) {
+	var defaultLevel *Level
+	var rules []levelRule
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		equalIndex := strings.IndexByte(entry, '=')
+		if equalIndex == -1 {
+			level, err := ParseLevel(entry)
+			if err != nil {
+				return fmt.Errorf("%w: %s: %s", ErrLevelSpecEntryNotValid, entry, err)
+			}
+			defaultLevel = &level
+			continue
+		}
+
+		pattern := strings.TrimSpace(entry[:equalIndex])
+		if pattern == "" {
+			return fmt.Errorf("%w: %s: pattern is empty", ErrLevelSpecEntryNotValid, entry)
+		}
+
+		_, err = path.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrLevelSpecPatternBad, pattern)
+		}
+
+		level, err := ParseLevel(strings.TrimSpace(entry[equalIndex+1:]))
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrLevelSpecEntryNotValid, entry, err)
+		}
+
+		rules = append(rules, levelRule{pattern: pattern, level: level})
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.spec = spec
+	s.defaultLevel = defaultLevel
+	s.rules = rules
+	s.cache = make(map[string]resolvedLevel)
+	return nil
+}
+
+// String returns the level specification string.
+func (s *LevelSpec) String() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.spec
+}
+
+// Level returns the level for the component given, and false if
+// no entry matches the component and there is no default level.
+// The separator is used to split the component path, such that
+// the entry matching the deepest component path is used. If
+// several entries match the same component path, the last one
+// in the specification is used.
+func (s *LevelSpec) Level(component, separator string) (level Level, ok bool) {
+	cacheKey := component + "\x00" + separator
+
+	s.mutex.RLock()
+	resolved, cached := s.cache[cacheKey]
+	s.mutex.RUnlock()
+	if cached {
+		return resolved.level, resolved.ok
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	level, ok = s.resolve(component, separator)
+	s.cache[cacheKey] = resolvedLevel{level: level, ok: ok}
+	return level, ok
+}
+
+func (s *LevelSpec) resolve(component, separator string) (level Level, ok bool) {
+	if component != "" {
+		parts := []string{component}
+		if separator != "" {
+			parts = strings.Split(component, separator)
+		}
+
+		for depth := len(parts); depth > 0; depth-- {
+			componentPath := strings.Join(parts[:depth], separator)
+			for i := len(s.rules) - 1; i >= 0; i-- {
+				matched, _ := path.Match(s.rules[i].pattern, componentPath)
+				if matched {
+					return s.rules[i].level, true
+				}
+			}
+		}
+	}
+
+	if s.defaultLevel != nil {
+		return *s.defaultLevel, true
+	}
+	return 0, false
+}
@@ -0,0 +1,207 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseLevelSpec(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		spec       string
+		err        error
+		errMessage string
+	}{
+		"empty": {},
+		"valid": {
+			spec: "info, http=debug,db/*=WARN,",
+		},
+		"invalid default level": {
+			spec:       "verbose",
+			err:        ErrLevelSpecEntryNotValid,
+			errMessage: "level spec entry is not valid: verbose: level is not recognized: verbose",
+		},
+		"empty pattern": {
+			spec:       "=debug",
+			err:        ErrLevelSpecEntryNotValid,
+			errMessage: "level spec entry is not valid: =debug: pattern is empty",
+		},
+		"bad pattern": {
+			spec:       "[=debug",
+			err:        ErrLevelSpecPatternBad,
+			errMessage: "level spec pattern is malformed: [",
+		},
+		"invalid level": {
+			spec:       "http=verbose",
+			err:        ErrLevelSpecEntryNotValid,
+			errMessage: "level spec entry is not valid: http=verbose: level is not recognized: verbose",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			levelSpec, err := ParseLevelSpec(testCase.spec)
+
+			assert.ErrorIs(t, err, testCase.err)
+			if testCase.err != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				assert.Nil(t, levelSpec)
+			} else {
+				assert.Equal(t, testCase.spec, levelSpec.String())
+			}
+		})
+	}
+}
+
+func Test_LevelSpec_Level(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		spec      string
+		component string
+		separator string
+		level     Level
+		ok        bool
+	}{
+		"no match and no default": {
+			spec:      "http=debug",
+			component: "db",
+			separator: "/",
+		},
+		"default level": {
+			spec:      "warn,http=debug",
+			component: "db",
+			separator: "/",
+			level:     LevelWarn,
+			ok:        true,
+		},
+		"empty component": {
+			spec:      "warn,*=debug",
+			separator: "/",
+			level:     LevelWarn,
+			ok:        true,
+		},
+		"exact match": {
+			spec:      "info,http=debug",
+			component: "http",
+			separator: "/",
+			level:     LevelDebug,
+			ok:        true,
+		},
+		"glob match": {
+			spec:      "info,db*=error",
+			component: "dbpool",
+			separator: "/",
+			level:     LevelError,
+			ok:        true,
+		},
+		"parent path match": {
+			spec:      "info,api=debug",
+			component: "api/auth/jwt",
+			separator: "/",
+			level:     LevelDebug,
+			ok:        true,
+		},
+		"deepest path wins": {
+			spec:      "info,api/auth=warn,api=debug",
+			component: "api/auth/jwt",
+			separator: "/",
+			level:     LevelWarn,
+			ok:        true,
+		},
+		"last entry wins": {
+			spec:      "http=debug,http=error",
+			component: "http",
+			separator: "/",
+			level:     LevelError,
+			ok:        true,
+		},
+		"glob does not cross separator": {
+			spec:      "info,api/*=debug",
+			component: "api",
+			separator: "/",
+			level:     LevelInfo,
+			ok:        true,
+		},
+		"other separator": {
+			spec:      "info,api=debug",
+			component: "api.auth",
+			separator: ".",
+			level:     LevelDebug,
+			ok:        true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			levelSpec, err := ParseLevelSpec(testCase.spec)
+			require.NoError(t, err)
+
+			level, ok := levelSpec.Level(testCase.component, testCase.separator)
+			assert.Equal(t, testCase.level, level)
+			assert.Equal(t, testCase.ok, ok)
+
+			// second call uses the cache
+			level, ok = levelSpec.Level(testCase.component, testCase.separator)
+			assert.Equal(t, testCase.level, level)
+			assert.Equal(t, testCase.ok, ok)
+		})
+	}
+}
+
+func Test_Logger_SetLevelSpec(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+
+	levelSpec, err := ParseLevelSpec("info,http=debug,db=warn")
+	require.NoError(t, err)
+
+	root := New(SetWriters(buffer), SetLevelSpec(levelSpec))
+	http := root.New(SetComponent("http"))
+	db := root.New(SetComponent("db"))
+
+	root.Debug("root debug")
+	root.Info("root info")
+	http.Debug("http debug")
+	db.Info("db info")
+	db.Warn("db warn")
+
+	err = levelSpec.Update("error,db=debug")
+	require.NoError(t, err)
+
+	root.Info("root info")
+	http.Info("http info")
+	db.Debug("db debug")
+
+	err = levelSpec.Update("db=invalid")
+	require.Error(t, err)
+	assert.Equal(t, "error,db=debug", levelSpec.String())
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+
+	expectedRegexes := []string{
+		timePrefixRegex + `INFO root info$`,
+		timePrefixRegex + `DEBUG \[http\] http debug$`,
+		timePrefixRegex + `WARN \[db\] db warn$`,
+		timePrefixRegex + `DEBUG \[db\] db debug$`,
+	}
+	require.Equal(t, len(expectedRegexes), len(lines))
+	for i := range lines {
+		regex := regexp.MustCompile(expectedRegexes[i])
+		assert.True(t, regex.MatchString(lines[i]),
+			"line %q does not match regex %q", lines[i], expectedRegexes[i])
+	}
+}
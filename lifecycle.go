@@ -0,0 +1,157 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+type flusher interface {
+	Flush() error
+}
+
+type syncer interface {
+	Sync() error
+}
+
+// Sync writes the pending duplicate summaries if deduplication
+// is enabled, and then flushes and syncs the writers of the logger
+// implementing a `Flush() error` and/or `Sync() error` method,
+// such as *bufio.Writer and *os.File. Errors syncing writers
+// not supporting it, such as terminals and pipes, are ignored.
+// It returns the first error encountered, after trying to
+// flush and sync all the writers.
+// It should be called before the program exits.
+func (l *Logger) Sync() (err error) {
+	if l.members != nil {
+		return forwardToMembers(l.members, func(member interface{}) error {
+			if syncer, ok := member.(syncer); ok {
+				return syncer.Sync()
+			}
+			return nil
+		})
+	}
+
+	l.FlushDuplicates()
+
+	l.settingsMutex.RLock()
+	writers := l.settings.writers
+	// writers mutexes are read while the settings mutex is locked
+	// so they are consistent with the writers.
+	l.writersMutexesMutex.RLock()
+	writersMutexes := l.writersMutexes
+	l.writersMutexesMutex.RUnlock()
+	l.settingsMutex.RUnlock()
+
+	for i, writer := range writers {
+		syncErr := syncWriter(writersMutexes[i], writer)
+		if syncErr != nil && err == nil {
+			err = fmt.Errorf("syncing writer %s: %w", writerName(writer), syncErr)
+		}
+	}
+	return err
+}
+
+func syncWriter(writerMutex *sync.Mutex, writer io.Writer) (err error) {
+	if writerMutex != nil {
+		writerMutex.Lock()
+		defer writerMutex.Unlock()
+	}
+
+	if flusher, ok := writer.(flusher); ok {
+		err = flusher.Flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	if syncer, ok := writer.(syncer); ok {
+		err = syncer.Sync()
+		if isSyncNotSupported(err) {
+			// file does not support syncing, such as a terminal or pipe
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Close syncs the writers of the logger as Sync does, closes
+// the writers owned by the logger implementing io.Closer and
+// releases the writers from the registry used to serialize
+// writes across loggers.
+// A logger owns the writers given to its constructor or to
+// its Patch method with the SetWriters or AddWriters options.
+// A child logger does not own the writers it inherits from its
+// parent logger, so closing a child logger does not close them.
+// However, closing a parent logger closes its owned writers
+// which may still be used by its child loggers, so child loggers
+// should be closed or no longer used before their parent logger
+// is closed. The os.Stdout and os.Stderr writers are never closed.
+// The logger must not be used after being closed, and closing
+// it again does nothing.
+// It returns the first error encountered, after trying to
+// sync and close all the writers.
+func (l *Logger) Close() (err error) {
+	if l.members != nil {
+		if !l.ownsMembers {
+			// the member loggers are owned by the caller of Multi
+			return l.Sync()
+		}
+		return forwardToMembers(l.members, func(member interface{}) error {
+			if closer, ok := member.(io.Closer); ok {
+				return closer.Close()
+			}
+			return nil
+		})
+	}
+
+	l.settingsMutex.Lock()
+	alreadyClosed := l.closed
+	l.closed = true
+	l.settingsMutex.Unlock()
+	if alreadyClosed {
+		return nil
+	}
+
+	err = l.Sync()
+
+	l.settingsMutex.Lock()
+	defer l.settingsMutex.Unlock()
+
+	for _, writer := range l.ownedWriters {
+		closer, ok := writer.(io.Closer)
+		if !ok || writer == os.Stdout || writer == os.Stderr {
+			continue
+		}
+
+		closeErr := closer.Close()
+		if closeErr != nil && err == nil {
+			err = fmt.Errorf("closing writer %s: %w", writerName(writer), closeErr)
+		}
+	}
+	l.ownedWriters = nil
+
+	writersRegistry.ReleaseWriters(l.settings.writers)
+	l.settings.writers = nil
+	l.writersMutexesMutex.Lock()
+	l.writersMutexes = nil
+	l.writersMutexesMutex.Unlock()
+
+	return err
+}
+
+// forwardToMembers calls the function given for each member
+// logger, and returns the first error encountered.
+func forwardToMembers(members []LoggerInterface,
+	call func(member interface{}) error) (err error) {
+	for _, member := range members {
+		memberErr := call(member)
+		if memberErr != nil && err == nil {
+			err = memberErr
+		}
+	}
+	return err
+}
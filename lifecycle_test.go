@@ -0,0 +1,116 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFlushWriter struct {
+	bytes.Buffer
+	flushErr error
+	flushed  bool
+}
+
+func (w *testFlushWriter) Flush() error {
+	w.flushed = true
+	return w.flushErr
+}
+
+func Test_Logger_Sync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flush and sync", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := bytes.NewBuffer(nil)
+		bufferedWriter := bufio.NewWriter(buffer)
+		file, err := os.Create(filepath.Join(t.TempDir(), "log"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = file.Close() })
+
+		logger := New(SetWriters(bufferedWriter, file, os.Stdout), SetTimeFormat(""),
+			SetLevel(LevelError))
+		logger.Error("message")
+		assert.Empty(t, buffer.String())
+
+		err = logger.Sync()
+
+		require.NoError(t, err)
+		assert.Equal(t, "ERROR message\n", buffer.String())
+	})
+
+	t.Run("flush error", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		writerA := &testFlushWriter{flushErr: errTest}
+		writerB := &testFlushWriter{}
+		logger := New(SetWriters(writerA, writerB))
+
+		err := logger.Sync()
+
+		assert.ErrorIs(t, err, errTest)
+		assert.True(t, writerA.flushed)
+		assert.True(t, writerB.flushed)
+	})
+}
+
+func Test_Logger_Close(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	parentFile, err := os.Create(filepath.Join(directory, "parent"))
+	require.NoError(t, err)
+	childFile, err := os.Create(filepath.Join(directory, "child"))
+	require.NoError(t, err)
+
+	parent := New(SetWriters(parentFile, os.Stdout), SetTimeFormat(""))
+	child := parent.New(AddWriters(childFile))
+	inheritingChild := parent.New(SetComponent("child"))
+
+	err = inheritingChild.Close()
+	require.NoError(t, err)
+	parent.Info("parent")
+
+	err = child.Close()
+	require.NoError(t, err)
+	_, err = childFile.WriteString("x")
+	assert.ErrorIs(t, err, os.ErrClosed)
+	parent.Info("parent")
+
+	err = parent.Close()
+	require.NoError(t, err)
+	_, err = parentFile.WriteString("x")
+	assert.ErrorIs(t, err, os.ErrClosed)
+
+	data, err := os.ReadFile(parentFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "INFO parent\nINFO parent\n", string(data))
+}
+
+func Test_Logger_Close_twice(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+	parent := New(SetWriters(buffer))
+	child := parent.New()
+
+	err := child.Close()
+	require.NoError(t, err)
+	err = child.Close()
+	require.NoError(t, err)
+
+	logger := New(SetWriters(buffer))
+	require.Len(t, logger.writersMutexes, 1)
+	require.Len(t, parent.writersMutexes, 1)
+	assert.Same(t, parent.writersMutexes[0], logger.writersMutexes[0])
+	assert.Empty(t, child.settings.writers)
+	assert.Empty(t, child.writersMutexes)
+}
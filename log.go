@@ -1,89 +1,285 @@
 package log
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
-	"github.com/fatih/color"
 	"github.com/qdm12/log/internal/caller"
+	"github.com/qdm12/log/internal/dedup"
+	"github.com/qdm12/log/internal/term"
 )
 
-func (l *Logger) logf(logLevel Level, format string, args ...interface{}) {
+// logf logs the message at the level given. The callerSkip
+// argument is the number of additional stack frames to skip
+// to determine the caller, for calls forwarded by a multi logger.
+func (l *Logger) logf(ctx context.Context, callerSkip uint, logLevel Level,
+	format string, args ...interface{}) {
+	if l.members != nil {
+		l.forward(ctx, callerSkip, logLevel, format, args)
+		return
+	}
+
+	l.log(ctx, logLevel, format, func(settings settings, now time.Time) Record {
+		message := format
+		if len(args) > 0 {
+			message = fmt.Sprintf(format, args...)
+		}
+
+		// skip the log and the record building function frames
+		const logSkip = 2
+		settings.caller.Skip += callerSkip + logSkip
+		return Record{
+			Level:     logLevel,
+			Time:      now,
+			Component: settings.component,
+			Message:   message,
+			Caller:    callerFromFrame(caller.Get(settings.caller)),
+		}
+	})
+}
+
+// LogRecord logs the record given as it is, without setting its
+// time, component or caller, which is useful to render records
+// parsed from another source. The record goes through the level
+// filter, sampling, hooks, redaction and deduplication of the
+// logger, and its timestamp is not logged if its time is zero.
+func (l *Logger) LogRecord(record Record) {
+	if l.members != nil {
+		l.forwardRecord(record)
+		return
+	}
+
+	l.log(context.Background(), record.Level, record.Message,
+		func(settings, time.Time) Record { return record })
+}
+
+// log builds a record with the build function given and writes
+// it, if the level given is enabled and the record is sampled
+// using the template given.
+func (l *Logger) log(ctx context.Context, logLevel Level, template string,
+	build func(settings settings, now time.Time) Record) {
 	l.settingsMutex.RLock()
-	defer l.settingsMutex.RUnlock()
 	settings := l.settings.copy()
+	l.writersMutexesMutex.RLock()
+	writersMutexes := l.writersMutexes
+	l.writersMutexesMutex.RUnlock()
+
+	// write failures are handled once the settings are unlocked,
+	// so the write error handler can use the logger.
+	var afterSummary func()
+	var failures []writeFailure
+	defer func() {
+		l.settingsMutex.RUnlock()
+		callAfter(afterSummary)
+		handleWriteFailures(settings.writeErrorHandler, failures)
+	}()
 
-	if *settings.level < logLevel {
+	if settings.effectiveLevel() < logLevel {
+		if settings.stats != nil {
+			settings.stats.addFiltered(logLevel)
+		}
 		return
 	}
 
-	var line string
-	if *settings.timeFormat != "" {
-		line += time.Now().Format(*settings.timeFormat) + " "
+	now := settings.now()
+
+	if settings.sampler != nil &&
+		!settings.sampler.Sample(now, uint8(logLevel), template) {
+		return
 	}
 
-	line += logLevel.ColoredString() + " "
-	if settings.component != "" {
-		line += "[" + settings.component + "] "
+	record := build(settings, now)
+
+	for _, extractor := range settings.contextExtractors {
+		record.Fields = append(record.Fields, extractor(ctx)...)
 	}
 
-	if len(args) == 0 {
-		line += format
-	} else {
-		line += fmt.Sprintf(format, args...)
+	record, keep := runHooks(settings.hooks, record)
+	if !keep {
+		return
 	}
 
-	callerString := caller.Line(settings.caller)
-	if callerString != "" {
-		line += "\t" + color.HiWhiteString(callerString)
+	record = redact(record, settings.redactedKeys, settings.redactors)
+
+	if settings.deduplicator != nil {
+		key := dedup.Key{
+			Level:     uint8(record.Level),
+			Component: record.Component,
+			Message:   record.Message,
+		}
+		summarize := func(repeated uint) (after func()) {
+			summaryTime := settings.now()
+			summary := Record{
+				Level:     record.Level,
+				Time:      summaryTime,
+				Component: record.Component,
+				Message:   fmt.Sprintf("last message repeated %d times", repeated),
+			}
+			line := formatRecord(settings, summary)
+			summaryFailures := write(settings, writersMutexes, summary, line, summaryTime)
+			if len(summaryFailures) == 0 {
+				return nil
+			}
+			return func() {
+				handleWriteFailures(settings.writeErrorHandler, summaryFailures)
+			}
+		}
+		var keep bool
+		keep, afterSummary = settings.deduplicator.Check(now, key, summarize)
+		if !keep {
+			return
+		}
 	}
 
-	line += "\n"
+	line := formatRecord(settings, record)
+	failures = write(settings, writersMutexes, record, line, now)
+}
+
+// write writes the record to the writers, and returns the write
+// failures to be given to the write error handler by the caller,
+// once it no longer holds any lock. The now argument is the time
+// used to track failing writers, since the record time may be zero.
+func write(settings settings, writersMutexes []*sync.Mutex,
+	record Record, formatted formattedLine, now time.Time) (failures []writeFailure) {
+	if settings.stats != nil {
+		settings.stats.addEmitted(record.Level, record.Component)
+	}
+
+	line := formatted.String()
 
-	l.writersMutexesMutex.RLock()
 	for i, writer := range settings.writers {
-		writerMutex := l.writersMutexes[i]
-		if writerMutex == nil {
-			// no need for a mutex, for example with io.Discard
-			_, _ = io.WriteString(writer, line)
+		writerLine := line
+		if formatted.rightAlignCaller {
+			if width := term.Width(writer); width > 0 {
+				writerLine = formatted.forTerminal(width)
+			}
+		}
+
+		var err error
+		if settings.writerBreaker != nil &&
+			!settings.writerBreaker.allow(writer, now) {
+			err = ErrWriterDisabled
 		} else {
-			writerMutex.Lock()
-			_, _ = io.WriteString(writer, line)
-			writerMutex.Unlock()
+			err = writeLocked(writersMutexes[i], writer, record, writerLine)
+			if settings.writerBreaker != nil {
+				settings.writerBreaker.report(writer, err, now)
+			}
+		}
+
+		if err == nil {
+			continue
+		}
+
+		if settings.stats != nil {
+			settings.stats.addWriteError(writer)
 		}
+
+		failures = append(failures, writeFailure{writer: writer, err: err, line: writerLine})
+	}
+	return failures
+}
+
+// writeFailure is a failed write of a line to a writer.
+type writeFailure struct {
+	writer io.Writer
+	err    error
+	line   string
+}
+
+func handleWriteFailures(handler WriteErrorHandler, failures []writeFailure) {
+	if handler == nil {
+		return
+	}
+	for _, failure := range failures {
+		handler(failure.writer, failure.err, failure.line)
 	}
-	l.writersMutexesMutex.RUnlock()
+}
+
+func callAfter(after func()) {
+	if after != nil {
+		after()
+	}
+}
+
+func writeLocked(writerMutex *sync.Mutex, writer io.Writer,
+	record Record, line string) (err error) {
+	if writerMutex == nil {
+		// no need for a mutex, for example with io.Discard
+		return writeTo(writer, record, line)
+	}
+	writerMutex.Lock()
+	defer writerMutex.Unlock()
+	return writeTo(writer, record, line)
+}
+
+func writeTo(writer io.Writer, record Record, line string) (err error) {
+	recordWriter, ok := writer.(RecordWriter)
+	if ok {
+		return recordWriter.WriteRecord(record)
+	}
+	_, err = io.WriteString(writer, line)
+	return err
 }
 
 // Debug logs with the debug level.
-func (l *Logger) Debug(s string) { l.logf(LevelDebug, s) }
+func (l *Logger) Debug(s string) { l.logf(context.Background(), 0, LevelDebug, s) }
 
 // Info logs with the info level.
-func (l *Logger) Info(s string) { l.logf(LevelInfo, s) }
+func (l *Logger) Info(s string) { l.logf(context.Background(), 0, LevelInfo, s) }
 
 // Warn logs with the warn level.
-func (l *Logger) Warn(s string) { l.logf(LevelWarn, s) }
+func (l *Logger) Warn(s string) { l.logf(context.Background(), 0, LevelWarn, s) }
 
 // Error logs with the error level.
-func (l *Logger) Error(s string) { l.logf(LevelError, s) }
+func (l *Logger) Error(s string) { l.logf(context.Background(), 0, LevelError, s) }
 
 // Debugf formats and logs at the debug level.
 func (l *Logger) Debugf(format string, args ...interface{}) {
-	l.logf(LevelDebug, format, args...)
+	l.logf(context.Background(), 0, LevelDebug, format, args...)
 }
 
 // Infof formats and logs at the info level.
 func (l *Logger) Infof(format string, args ...interface{}) {
-	l.logf(LevelInfo, format, args...)
+	l.logf(context.Background(), 0, LevelInfo, format, args...)
 }
 
 // Warnf formats and logs at the warn level.
 func (l *Logger) Warnf(format string, args ...interface{}) {
-	l.logf(LevelWarn, format, args...)
+	l.logf(context.Background(), 0, LevelWarn, format, args...)
 }
 
 // Errorf formats and logs at the error level.
 func (l *Logger) Errorf(format string, args ...interface{}) {
-	l.logf(LevelError, format, args...)
+	l.logf(context.Background(), 0, LevelError, format, args...)
+}
+
+// DebugContext formats and logs at the debug level, with fields
+// extracted from the context by the logger context extractors.
+// If no argument is given, the format string is logged as is.
+func (l *Logger) DebugContext(ctx context.Context, format string, args ...interface{}) {
+	l.logf(ctx, 0, LevelDebug, format, args...)
+}
+
+// InfoContext formats and logs at the info level, with fields
+// extracted from the context by the logger context extractors.
+// If no argument is given, the format string is logged as is.
+func (l *Logger) InfoContext(ctx context.Context, format string, args ...interface{}) {
+	l.logf(ctx, 0, LevelInfo, format, args...)
+}
+
+// WarnContext formats and logs at the warn level, with fields
+// extracted from the context by the logger context extractors.
+// If no argument is given, the format string is logged as is.
+func (l *Logger) WarnContext(ctx context.Context, format string, args ...interface{}) {
+	l.logf(ctx, 0, LevelWarn, format, args...)
+}
+
+// ErrorContext formats and logs at the error level, with fields
+// extracted from the context by the logger context extractors.
+// If no argument is given, the format string is logged as is.
+func (l *Logger) ErrorContext(ctx context.Context, format string, args ...interface{}) {
+	l.logf(ctx, 0, LevelError, format, args...)
 }
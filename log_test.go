@@ -2,6 +2,7 @@ package log
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"regexp"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/qdm12/log/internal/caller"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,10 +28,13 @@ func Test_Logger_log(t *testing.T) {
 		"log at info with debug set": {
 			logger: &Logger{
 				settings: settings{
-					writers:    []io.Writer{bytes.NewBuffer(nil)},
-					level:      levelPtr(LevelDebug),
-					timeFormat: stringPtr(time.RFC3339),
-					caller:     newCallerSettings(false, false, false),
+					writers:       []io.Writer{bytes.NewBuffer(nil)},
+					level:         levelPtr(LevelDebug),
+					timeFormat:    stringPtr(time.RFC3339),
+					multiline:     multilinePtr(MultilineRaw),
+					timestampMode: timestampModePtr(TimestampLayout),
+					theme:         themePtr(DefaultTheme()),
+					caller:        newCallerSettings(false, false, false),
 				},
 				writersMutexes: []*sync.Mutex{new(sync.Mutex)},
 			},
@@ -40,10 +45,13 @@ func Test_Logger_log(t *testing.T) {
 		"log at info with warn set": {
 			logger: &Logger{
 				settings: settings{
-					writers:    []io.Writer{bytes.NewBuffer(nil)},
-					level:      levelPtr(LevelWarn),
-					timeFormat: stringPtr(time.RFC3339),
-					caller:     newCallerSettings(false, false, false),
+					writers:       []io.Writer{bytes.NewBuffer(nil)},
+					level:         levelPtr(LevelWarn),
+					timeFormat:    stringPtr(time.RFC3339),
+					multiline:     multilinePtr(MultilineRaw),
+					timestampMode: timestampModePtr(TimestampLayout),
+					theme:         themePtr(DefaultTheme()),
+					caller:        newCallerSettings(false, false, false),
 				},
 				writersMutexes: []*sync.Mutex{new(sync.Mutex)},
 			},
@@ -54,10 +62,13 @@ func Test_Logger_log(t *testing.T) {
 		"format string": {
 			logger: &Logger{
 				settings: settings{
-					writers:    []io.Writer{bytes.NewBuffer(nil)},
-					level:      levelPtr(LevelDebug),
-					timeFormat: stringPtr(time.RFC3339),
-					caller:     newCallerSettings(false, false, false),
+					writers:       []io.Writer{bytes.NewBuffer(nil)},
+					level:         levelPtr(LevelDebug),
+					timeFormat:    stringPtr(time.RFC3339),
+					multiline:     multilinePtr(MultilineRaw),
+					timestampMode: timestampModePtr(TimestampLayout),
+					theme:         themePtr(DefaultTheme()),
+					caller:        newCallerSettings(false, false, false),
 				},
 				writersMutexes: []*sync.Mutex{new(sync.Mutex)},
 			},
@@ -69,10 +80,13 @@ func Test_Logger_log(t *testing.T) {
 		"show caller": {
 			logger: &Logger{
 				settings: settings{
-					writers:    []io.Writer{bytes.NewBuffer(nil)},
-					level:      levelPtr(LevelDebug),
-					timeFormat: stringPtr(time.RFC3339),
-					caller:     newCallerSettings(true, true, true),
+					writers:       []io.Writer{bytes.NewBuffer(nil)},
+					level:         levelPtr(LevelDebug),
+					timeFormat:    stringPtr(time.RFC3339),
+					multiline:     multilinePtr(MultilineRaw),
+					timestampMode: timestampModePtr(TimestampLayout),
+					theme:         themePtr(DefaultTheme()),
+					caller:        newCallerSettings(true, true, true),
 				},
 				writersMutexes: []*sync.Mutex{new(sync.Mutex)},
 			},
@@ -80,6 +94,29 @@ func Test_Logger_log(t *testing.T) {
 			s:           "some words",
 			outputRegex: timePrefixRegex + "DEBUG some words\tlog_test.go:L[0-9]+:func[0-9]+\n$",
 		},
+		"show caller module path and package function": {
+			logger: &Logger{
+				settings: settings{
+					writers:       []io.Writer{bytes.NewBuffer(nil)},
+					level:         levelPtr(LevelDebug),
+					timeFormat:    stringPtr(time.RFC3339),
+					multiline:     multilinePtr(MultilineRaw),
+					timestampMode: timestampModePtr(TimestampLayout),
+					theme:         themePtr(DefaultTheme()),
+					caller: func() caller.Settings {
+						settings := newCallerSettings(true, true, true)
+						pathMode, funcMode := caller.PathModule, caller.FuncPackage
+						settings.PathMode, settings.FuncMode = &pathMode, &funcMode
+						return settings
+					}(),
+				},
+				writersMutexes: []*sync.Mutex{new(sync.Mutex)},
+			},
+			level: LevelDebug,
+			s:     "some words",
+			outputRegex: timePrefixRegex +
+				`DEBUG some words\tlog_test.go:L[0-9]+:log\.Test_Logger_log\.func[0-9]+\n$`,
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -93,7 +130,7 @@ func Test_Logger_log(t *testing.T) {
 			require.True(t, ok)
 
 			logWrapper := func() { // wrap for caller depth of 3
-				testCase.logger.logf(testCase.level, testCase.s, testCase.args...)
+				testCase.logger.logf(context.Background(), 0, testCase.level, testCase.s, testCase.args...)
 			}
 
 			logWrapper()
@@ -154,3 +191,83 @@ func Test_Logger_LevelsLog(t *testing.T) {
 			"line %q does not match regex %q", lines[i], expectedRegexes[i])
 	}
 }
+
+func Test_Logger_clockAndTimeZone(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+	clock := func() time.Time {
+		return time.Date(2022, time.March, 28, 10, 3, 29, 0, time.UTC)
+	}
+
+	logger := New(SetWriters(buffer), SetClock(clock),
+		SetTimeZone(time.FixedZone("UTC+2", 2*60*60)))
+	logger.Info("parent")
+	child := logger.New(SetComponent("child"))
+	child.Info("child")
+	utcChild := logger.New(SetTimeZone(time.UTC))
+	utcChild.Info("utc child")
+
+	const expected = "2022-03-28T12:03:29+02:00 INFO parent\n" +
+		"2022-03-28T12:03:29+02:00 INFO [child] child\n" +
+		"2022-03-28T10:03:29Z INFO utc child\n"
+	assert.Equal(t, expected, buffer.String())
+}
+
+type testRecordWriter struct {
+	records []Record
+}
+
+func (w *testRecordWriter) Write(p []byte) (n int, err error) {
+	panic("Write should not be called")
+}
+
+func (w *testRecordWriter) WriteRecord(record Record) error {
+	w.records = append(w.records, record)
+	return nil
+}
+
+func Test_Logger_RecordWriter(t *testing.T) {
+	t.Parallel()
+
+	recordWriter := &testRecordWriter{}
+	buffer := bytes.NewBuffer(nil)
+
+	logger := New(SetWriters(recordWriter, buffer), SetComponent("component"))
+	logger.Warnf("some %s", "message")
+
+	require.Len(t, recordWriter.records, 1)
+	record := recordWriter.records[0]
+	assert.Equal(t, LevelWarn, record.Level)
+	assert.Equal(t, "component", record.Component)
+	assert.Equal(t, "some message", record.Message)
+	assert.False(t, record.Time.IsZero())
+
+	regex := regexp.MustCompile(timePrefixRegex + `WARN \[component\] some message\n$`)
+	assert.True(t, regex.MatchString(buffer.String()))
+}
+
+func Test_Logger_LogRecord(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+	logger := New(SetWriters(buffer), SetLevel(LevelInfo),
+		SetComponent("ignored"), SetCallerFunc(true),
+		RedactKeys("token"))
+
+	logger.LogRecord(Record{
+		Level:     LevelWarn,
+		Time:      time.Date(2022, time.March, 28, 10, 3, 29, 0, time.UTC),
+		Component: "api",
+		Message:   "slow request",
+		Caller:    Caller{Function: "main.run"},
+		Fields:    []Field{{Key: "token", Value: "secret"}},
+	})
+	logger.LogRecord(Record{Level: LevelDebug, Message: "filtered"})
+	multi := Multi(logger)
+	multi.LogRecord(Record{Level: LevelError, Message: "no time"})
+
+	const expected = "2022-03-28T10:03:29Z WARN [api] slow request token=[REDACTED]\trun\n" +
+		"ERROR no time\n"
+	assert.Equal(t, expected, buffer.String())
+}
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"io"
 	"sync"
 
 	"github.com/qdm12/log/internal/writersreg"
@@ -17,6 +18,22 @@ type Logger struct {
 	// matching the order of settings.writers.
 	writersMutexes      []*sync.Mutex
 	writersMutexesMutex sync.RWMutex
+	// ownedWriters are the writers set by the options given
+	// to the logger constructor or to Patch, and are closed
+	// when the logger is closed. It is protected by the
+	// settingsMutex.
+	ownedWriters []io.Writer
+	// closed is set once the logger is closed, such that
+	// closing it again does nothing. It is protected by the
+	// settingsMutex.
+	closed bool
+	// members are the loggers to forward calls to, and
+	// is only set for a logger created with Multi.
+	members []LoggerInterface
+	// ownsMembers is true if the members were created by the
+	// logger, in which case they are closed when the logger is
+	// closed.
+	ownsMembers bool
 }
 
 // New creates a new logger, with thread safety each of
@@ -24,6 +41,7 @@ type Logger struct {
 // to configure the logger.
 func New(options ...Option) *Logger {
 	settings := newSettings(options)
+	ownedWriters := settings.writers
 	settings.setDefaults()
 
 	writerMutexes := writersRegistry.RegisterWriters(settings.writers)
@@ -31,6 +49,7 @@ func New(options ...Option) *Logger {
 	return &Logger{
 		settings:       settings,
 		writersMutexes: writerMutexes,
+		ownedWriters:   ownedWriters,
 	}
 }
 
@@ -38,6 +57,14 @@ func New(options ...Option) *Logger {
 // the current logger. Options can be passed to modify
 // the settings of the new child logger to be created.
 func (l *Logger) New(options ...Option) *Logger {
+	if l.members != nil {
+		children := make([]LoggerInterface, len(l.members))
+		for i, member := range l.members {
+			children[i] = member.New(options...)
+		}
+		return &Logger{members: children, ownsMembers: true}
+	}
+
 	newSettings := newSettings(options)
 
 	l.settingsMutex.RLock()
@@ -52,5 +79,6 @@ func (l *Logger) New(options ...Option) *Logger {
 	return &Logger{
 		settings:       childSettings,
 		writersMutexes: writersMutexes,
+		ownedWriters:   newSettings.writers,
 	}
 }
@@ -20,10 +20,15 @@ func Test_New(t *testing.T) {
 		"no option": {
 			expectedLogger: &Logger{
 				settings: settings{
-					writers:    []io.Writer{os.Stdout},
-					level:      levelPtr(LevelInfo),
-					timeFormat: stringPtr(time.RFC3339),
-					caller:     newCallerSettings(false, false, false),
+					writers:            []io.Writer{os.Stdout},
+					level:              levelPtr(LevelInfo),
+					timeFormat:         stringPtr(time.RFC3339),
+					multiline:          multilinePtr(MultilineRaw),
+					timestampMode:      timestampModePtr(TimestampLayout),
+					theme:              themePtr(DefaultTheme()),
+					componentSeparator: stringPtr("/"),
+					caller:             newCallerSettings(false, false, false),
+					stats:              newStatsCollector(),
 				},
 				writersMutexes: []*sync.Mutex{new(sync.Mutex)},
 			},
@@ -39,12 +44,18 @@ func Test_New(t *testing.T) {
 			},
 			expectedLogger: &Logger{
 				settings: settings{
-					writers:    []io.Writer{io.Discard},
-					level:      levelPtr(LevelInfo),
-					timeFormat: stringPtr(time.RFC1123),
-					caller:     newCallerSettings(true, true, true),
+					writers:            []io.Writer{io.Discard},
+					level:              levelPtr(LevelInfo),
+					timeFormat:         stringPtr(time.RFC1123),
+					multiline:          multilinePtr(MultilineRaw),
+					timestampMode:      timestampModePtr(TimestampLayout),
+					theme:              themePtr(DefaultTheme()),
+					componentSeparator: stringPtr("/"),
+					caller:             newCallerSettings(true, true, true),
+					stats:              newStatsCollector(),
 				},
 				writersMutexes: []*sync.Mutex{nil},
+				ownedWriters:   []io.Writer{io.Discard},
 			},
 		},
 	}
@@ -110,6 +121,7 @@ func Test_Logger_New(t *testing.T) {
 					caller:     newCallerSettings(true, true, false),
 				},
 				writersMutexes: []*sync.Mutex{new(sync.Mutex)},
+				ownedWriters:   []io.Writer{os.Stderr},
 			},
 		},
 	}
@@ -0,0 +1,172 @@
+package log
+
+import (
+	"context"
+	"io"
+
+	"github.com/qdm12/log/internal/sampling"
+)
+
+// Multi returns a logger forwarding each log call to all the
+// loggers given, which can each have their own settings, such
+// as a component logger and an audit logger.
+// Calling New on the returned logger creates a child logger of
+// each of the loggers given and returns a multi logger of these
+// children. Calling Patch on the returned logger patches each of
+// the loggers given. Sync and FlushDuplicates are also forwarded
+// to the loggers given implementing them. Close only syncs the
+// loggers given since they are owned by the caller, but closes
+// the children loggers of a multi logger created with New.
+// Stats, SampledOut and Writers aggregate the counters and
+// writers of the loggers given.
+func Multi(loggers ...LoggerInterface) *Logger {
+	members := make([]LoggerInterface, len(loggers))
+	copy(members, loggers)
+	return &Logger{
+		members: members,
+	}
+}
+
+// forward forwards the log call to each member logger.
+func (l *Logger) forward(ctx context.Context, callerSkip uint,
+	level Level, format string, args []interface{}) {
+	for _, member := range l.members {
+		switch typedMember := member.(type) {
+		case *Logger:
+			// skip the forward and logf frames of this logger
+			const forwardSkip = 2
+			typedMember.logf(ctx, callerSkip+forwardSkip, level, format, args...)
+		case ContextLogger:
+			forwardContext(ctx, typedMember, level, format, args)
+		default:
+			forwardLeveled(typedMember, level, format, args)
+		}
+	}
+}
+
+// forwardRecord forwards the record to each member logger. The
+// record fields and caller are lost for member loggers other than
+// *Logger, which are given the record message only.
+func (l *Logger) forwardRecord(record Record) {
+	for _, member := range l.members {
+		if typedMember, ok := member.(*Logger); ok {
+			typedMember.LogRecord(record)
+			continue
+		}
+		forwardLeveled(member, record.Level, record.Message, nil)
+	}
+}
+
+func forwardContext(ctx context.Context, logger ContextLogger,
+	level Level, format string, args []interface{}) {
+	switch level {
+	case LevelDebug:
+		logger.DebugContext(ctx, format, args...)
+	case LevelInfo:
+		logger.InfoContext(ctx, format, args...)
+	case LevelWarn:
+		logger.WarnContext(ctx, format, args...)
+	case LevelError:
+		logger.ErrorContext(ctx, format, args...)
+	}
+}
+
+func forwardLeveled(logger LeveledLogger, level Level,
+	format string, args []interface{}) {
+	if len(args) == 0 {
+		// the format string is the message to log as is
+		switch level {
+		case LevelDebug:
+			logger.Debug(format)
+		case LevelInfo:
+			logger.Info(format)
+		case LevelWarn:
+			logger.Warn(format)
+		case LevelError:
+			logger.Error(format)
+		}
+		return
+	}
+
+	switch level {
+	case LevelDebug:
+		logger.Debugf(format, args...)
+	case LevelInfo:
+		logger.Infof(format, args...)
+	case LevelWarn:
+		logger.Warnf(format, args...)
+	case LevelError:
+		logger.Errorf(format, args...)
+	}
+}
+
+// membersStats returns the stats of the member loggers merged
+// together. Counters shared between member loggers, such as
+// between a parent logger and its child logger, are counted once.
+func (l *Logger) membersStats() (stats Stats) {
+	stats = newStatsCollector().snapshot()
+	collectors := make(map[*statsCollector]struct{}, len(l.members))
+	for _, member := range l.members {
+		if logger, ok := member.(*Logger); ok && logger.members == nil {
+			logger.settingsMutex.RLock()
+			collector := logger.settings.stats
+			logger.settingsMutex.RUnlock()
+
+			if _, seen := collectors[collector]; collector == nil || seen {
+				continue
+			}
+			collectors[collector] = struct{}{}
+			stats.merge(collector.snapshot())
+			continue
+		}
+
+		if statsGetter, ok := member.(interface{ Stats() Stats }); ok {
+			stats.merge(statsGetter.Stats())
+		}
+	}
+	return stats
+}
+
+// membersSampledOut returns the number of records dropped by
+// the samplers of the member loggers. Samplers shared between
+// member loggers are counted once.
+func (l *Logger) membersSampledOut() (dropped uint64) {
+	samplers := make(map[*sampling.Sampler]struct{}, len(l.members))
+	for _, member := range l.members {
+		if logger, ok := member.(*Logger); ok && logger.members == nil {
+			logger.settingsMutex.RLock()
+			sampler := logger.settings.sampler
+			logger.settingsMutex.RUnlock()
+
+			if _, seen := samplers[sampler]; sampler == nil || seen {
+				continue
+			}
+			samplers[sampler] = struct{}{}
+			dropped += sampler.Dropped()
+			continue
+		}
+
+		if sampledOutGetter, ok := member.(interface{ SampledOut() uint64 }); ok {
+			dropped += sampledOutGetter.SampledOut()
+		}
+	}
+	return dropped
+}
+
+// membersWriters returns the writers of the member loggers,
+// without duplicates.
+func (l *Logger) membersWriters() (writers []io.Writer) {
+	writers = []io.Writer{}
+	for _, member := range l.members {
+		writersGetter, ok := member.(interface{ Writers() []io.Writer })
+		if !ok {
+			continue
+		}
+		for _, writer := range writersGetter.Writers() {
+			if !containsWriter(writers, writer) {
+				writers = append(writers, writer)
+			}
+		}
+	}
+	return writers
+}
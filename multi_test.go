@@ -0,0 +1,158 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLeveledLogger records the calls made to it.
+type testLeveledLogger struct {
+	calls   []string
+	options []Option
+}
+
+func (l *testLeveledLogger) Debug(s string) { l.calls = append(l.calls, "Debug "+s) }
+func (l *testLeveledLogger) Info(s string)  { l.calls = append(l.calls, "Info "+s) }
+func (l *testLeveledLogger) Warn(s string)  { l.calls = append(l.calls, "Warn "+s) }
+func (l *testLeveledLogger) Error(s string) { l.calls = append(l.calls, "Error "+s) }
+
+func (l *testLeveledLogger) Debugf(format string, args ...interface{}) {
+	l.calls = append(l.calls, "Debugf "+fmt.Sprintf(format, args...))
+}
+
+func (l *testLeveledLogger) Infof(format string, args ...interface{}) {
+	l.calls = append(l.calls, "Infof "+fmt.Sprintf(format, args...))
+}
+
+func (l *testLeveledLogger) Warnf(format string, args ...interface{}) {
+	l.calls = append(l.calls, "Warnf "+fmt.Sprintf(format, args...))
+}
+
+func (l *testLeveledLogger) Errorf(format string, args ...interface{}) {
+	l.calls = append(l.calls, "Errorf "+fmt.Sprintf(format, args...))
+}
+
+func (l *testLeveledLogger) Patch(options ...Option) {
+	l.options = append(l.options, options...)
+}
+
+func (l *testLeveledLogger) New(options ...Option) *Logger {
+	l.options = append(l.options, options...)
+	return Multi(l)
+}
+
+func Test_Multi(t *testing.T) {
+	t.Parallel()
+
+	componentBuffer := bytes.NewBuffer(nil)
+	auditBuffer := bytes.NewBuffer(nil)
+	componentLogger := New(SetWriters(componentBuffer), SetTimeFormat(""),
+		SetComponent("component"), SetLevel(LevelDebug))
+	auditLogger := New(SetWriters(auditBuffer), SetTimeFormat(""),
+		SetLevel(LevelWarn), SetCallerFile(true), SetCallerLine(true))
+	leveledLogger := &testLeveledLogger{}
+
+	logger := Multi(componentLogger, auditLogger, leveledLogger)
+
+	logger.Debug("100%")
+	logger.Warnf("%d%%", 100)
+	line := currentLine() - 1
+	logger.InfoContext(context.Background(), "context %s", "call")
+
+	assert.Equal(t, "DEBUG [component] 100%\n"+
+		"WARN [component] 100%\n"+
+		"INFO [component] context call\n", componentBuffer.String())
+	assert.Equal(t, fmt.Sprintf("WARN 100%%\tmulti_test.go:L%d\n", line), auditBuffer.String())
+	assert.Equal(t, []string{"Debug 100%", "Warnf 100%", "Infof context call"}, leveledLogger.calls)
+
+	componentBuffer.Reset()
+	auditBuffer.Reset()
+
+	child := logger.New(SetComponent("child"))
+	nested := Multi(child)
+	nested.Error("error")
+	line = currentLine() - 1
+
+	assert.Equal(t, "ERROR [child] error\n", componentBuffer.String())
+	assert.Equal(t, fmt.Sprintf("ERROR [child] error\tmulti_test.go:L%d\n", line), auditBuffer.String())
+	require.Len(t, leveledLogger.options, 1)
+
+	componentBuffer.Reset()
+	auditBuffer.Reset()
+
+	logger.Patch(SetLevel(LevelError))
+	logger.Warn("warn")
+
+	assert.Empty(t, componentBuffer.String())
+	assert.Empty(t, auditBuffer.String())
+	assert.Len(t, leveledLogger.options, 2)
+
+	err := logger.Sync()
+	assert.NoError(t, err)
+}
+
+func Test_Multi_aggregates(t *testing.T) {
+	t.Parallel()
+
+	bufferA := bytes.NewBuffer(nil)
+	bufferB := bytes.NewBuffer(nil)
+	parent := New(SetWriters(bufferA), SetSampling(1, 0, time.Hour))
+	child := parent.New(SetComponent("child"))
+	other := New(SetWriters(bufferA, bufferB))
+
+	logger := Multi(parent, child, other)
+	logger.Info("message")
+	logger.Info("message")
+
+	// parent and child share their sampler and stats, so only
+	// the first record of the parent is let through and the
+	// shared counters are counted once.
+	expectedStats := Stats{
+		Emitted: map[Level]map[string]uint64{
+			LevelInfo: {"": 3},
+		},
+		Filtered:    map[Level]uint64{},
+		WriteErrors: map[string]uint64{},
+	}
+	assert.Equal(t, expectedStats, logger.Stats())
+	assert.Equal(t, uint64(3), logger.SampledOut())
+	assert.Equal(t, []io.Writer{bufferA, bufferB}, logger.Writers())
+}
+
+func Test_Multi_Close(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "log"))
+	require.NoError(t, err)
+	member := New(SetWriters(file), SetTimeFormat(""))
+
+	logger := Multi(member)
+	err = logger.Close()
+	require.NoError(t, err)
+
+	// the member logger given is not closed
+	member.Info("member")
+	err = member.Close()
+	require.NoError(t, err)
+	data, err := os.ReadFile(file.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "INFO member\n", string(data))
+
+	// children loggers created by the multi logger are closed
+	childFile, err := os.Create(filepath.Join(t.TempDir(), "child"))
+	require.NoError(t, err)
+	child := Multi(New()).New(SetWriters(childFile))
+	err = child.Close()
+	require.NoError(t, err)
+	_, err = childFile.WriteString("x")
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
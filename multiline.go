@@ -0,0 +1,55 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MultilineMode is the mode to render messages
+// spanning multiple lines.
+type MultilineMode uint8
+
+const (
+	// MultilineRaw writes continuation lines as they are,
+	// without any prefix.
+	MultilineRaw MultilineMode = iota
+	// MultilineIndent indents continuation lines to align
+	// them with the start of the message.
+	MultilineIndent
+	// MultilinePrefix prefixes each continuation line with
+	// the time, level and component of the record.
+	MultilinePrefix
+	// MultilineEscape escapes new lines as \n so each
+	// record is written on a single line.
+	MultilineEscape
+)
+
+func (m MultilineMode) String() string {
+	switch m {
+	case MultilineRaw:
+		return "raw"
+	case MultilineIndent:
+		return "indent"
+	case MultilinePrefix:
+		return "prefix"
+	case MultilineEscape:
+		return "escape"
+	default:
+		panic(fmt.Sprintf("multiline mode %d is unknown", m))
+	}
+}
+
+var ErrMultilineModeNotRecognized = errors.New("multiline mode is not recognized")
+
+// ParseMultilineMode parses a string into a multiline mode,
+// and returns an error if it fails.
+func ParseMultilineMode(s string) (mode MultilineMode, err error) {
+	for _, mode := range []MultilineMode{MultilineRaw, MultilineIndent,
+		MultilinePrefix, MultilineEscape} {
+		if strings.EqualFold(s, mode.String()) {
+			return mode, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrMultilineModeNotRecognized, s)
+}
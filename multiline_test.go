@@ -0,0 +1,50 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseMultilineMode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		s    string
+		mode MultilineMode
+		err  error
+	}{
+		"raw": {
+			s:    "raw",
+			mode: MultilineRaw,
+		},
+		"indent upper case": {
+			s:    "INDENT",
+			mode: MultilineIndent,
+		},
+		"prefix": {
+			s:    "prefix",
+			mode: MultilinePrefix,
+		},
+		"escape": {
+			s:    "escape",
+			mode: MultilineEscape,
+		},
+		"invalid": {
+			s:   "invalid",
+			err: ErrMultilineModeNotRecognized,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mode, err := ParseMultilineMode(testCase.s)
+
+			assert.ErrorIs(t, err, testCase.err)
+			assert.Equal(t, testCase.mode, mode)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package netwriter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Settings contains settings for the network writer.
+type Settings struct {
+	// Network is the network to use, such as "tcp" or "udp".
+	// It defaults to "tcp".
+	Network string
+	// Address is the address of the remote collector,
+	// for example "127.0.0.1:5000". It cannot be empty.
+	Address string
+	// MinBackoff is the initial waiting duration before
+	// trying to reconnect, which doubles after each failed
+	// attempt up to MaxBackoff.
+	// It defaults to 100ms.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum waiting duration between
+	// two connection attempts.
+	// It defaults to 30s.
+	MaxBackoff time.Duration
+	// DialTimeout is the timeout for each connection attempt.
+	// It defaults to 5s.
+	DialTimeout time.Duration
+	// WriteTimeout is the timeout to write data to the
+	// connection, after which the connection is considered
+	// broken and is re-established.
+	// It defaults to 5s.
+	WriteTimeout time.Duration
+	// BufferSize is the maximum number of bytes buffered while
+	// the connection is not established. Data written when the
+	// buffer is full is dropped.
+	// It defaults to 1MiB.
+	BufferSize int
+}
+
+func (s *Settings) setDefaults() {
+	if s.Network == "" {
+		s.Network = "tcp"
+	}
+
+	if s.MinBackoff == 0 {
+		const defaultMinBackoff = 100 * time.Millisecond
+		s.MinBackoff = defaultMinBackoff
+	}
+
+	if s.MaxBackoff == 0 {
+		const defaultMaxBackoff = 30 * time.Second
+		s.MaxBackoff = defaultMaxBackoff
+	}
+
+	const defaultTimeout = 5 * time.Second
+	if s.DialTimeout == 0 {
+		s.DialTimeout = defaultTimeout
+	}
+
+	if s.WriteTimeout == 0 {
+		s.WriteTimeout = defaultTimeout
+	}
+
+	if s.BufferSize == 0 {
+		const defaultBufferSize = 1024 * 1024
+		s.BufferSize = defaultBufferSize
+	}
+}
+
+var (
+	ErrAddressNotSet       = errors.New("address is not set")
+	ErrBackoffNotValid     = errors.New("backoff is not valid")
+	ErrBufferSizeNotValid  = errors.New("buffer size is not valid")
+	ErrNetworkNotSupported = errors.New("network is not supported")
+)
+
+func (s Settings) validate() (err error) {
+	switch s.Network {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6", "unix", "unixgram":
+	default:
+		return fmt.Errorf("%w: %s", ErrNetworkNotSupported, s.Network)
+	}
+
+	if s.Address == "" {
+		return fmt.Errorf("%w", ErrAddressNotSet)
+	}
+
+	if s.MinBackoff < 0 || s.MaxBackoff < s.MinBackoff {
+		return fmt.Errorf("%w: minimum %s and maximum %s",
+			ErrBackoffNotValid, s.MinBackoff, s.MaxBackoff)
+	}
+
+	if s.BufferSize < 0 {
+		return fmt.Errorf("%w: %d", ErrBufferSizeNotValid, s.BufferSize)
+	}
+
+	return nil
+}
@@ -0,0 +1,275 @@
+package netwriter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Writer is a network writer reconnecting automatically to
+// its remote address, which can be given to a logger with the
+// log.SetWriters or log.AddWriters options.
+// Each Write call is buffered and sent asynchronously, and is
+// sent as a single datagram for datagram networks.
+// It is thread safe to use.
+type Writer struct {
+	settings Settings
+	dial     dialFunc
+	// stream is true for stream oriented networks,
+	// such as tcp and unix.
+	stream bool
+
+	mutex         sync.Mutex
+	chunks        [][]byte
+	bufferedBytes int
+	droppedBytes  uint64
+	closed        bool
+
+	signal  chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// ErrWriterClosed is returned when writing to a closed writer.
+var ErrWriterClosed = errors.New("network writer is closed")
+
+// New creates a network writer and starts connecting to
+// the remote address in the background.
+// The Close method should be called to release resources.
+func New(settings Settings) (writer *Writer, err error) {
+	return newWriter(settings, net.DialTimeout)
+}
+
+type dialFunc func(network, address string, timeout time.Duration) (net.Conn, error)
+
+func newWriter(settings Settings, dial dialFunc) (writer *Writer, err error) {
+	settings.setDefaults()
+	err = settings.validate()
+	if err != nil {
+		return nil, fmt.Errorf("validating settings: %w", err)
+	}
+
+	writer = &Writer{
+		settings: settings,
+		dial:     dial,
+		stream:   isStreamNetwork(settings.Network),
+		signal:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+
+	go writer.run()
+
+	return writer, nil
+}
+
+func isStreamNetwork(network string) bool {
+	switch network {
+	case "udp", "udp4", "udp6", "unixgram":
+		return false
+	default:
+		return true
+	}
+}
+
+// Write buffers a copy of the data given to be sent to the
+// remote address. It never blocks on the network, and drops
+// the data if the buffer is full, in which case the dropped
+// bytes are counted and reported by DroppedBytes.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return 0, fmt.Errorf("%w", ErrWriterClosed)
+	}
+
+	if w.bufferedBytes+len(p) > w.settings.BufferSize {
+		w.droppedBytes += uint64(len(p))
+		return len(p), nil
+	}
+
+	chunk := make([]byte, len(p))
+	copy(chunk, p)
+	w.chunks = append(w.chunks, chunk)
+	w.bufferedBytes += len(p)
+
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+
+	return len(p), nil
+}
+
+// DroppedBytes returns the number of bytes dropped because
+// the buffer was full, or because they were written to a stream
+// connection found broken before it proved healthy again.
+func (w *Writer) DroppedBytes() uint64 {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.droppedBytes
+}
+
+// Close stops the writer, trying to send any buffered data
+// if the connection is established, and closes the connection.
+// Data still buffered after this is counted as dropped.
+func (w *Writer) Close() (err error) {
+	w.mutex.Lock()
+	if w.closed {
+		w.mutex.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mutex.Unlock()
+
+	close(w.done)
+	<-w.stopped
+
+	w.mutex.Lock()
+	w.droppedBytes += uint64(w.bufferedBytes)
+	w.chunks = nil
+	w.bufferedBytes = 0
+	w.mutex.Unlock()
+
+	return nil
+}
+
+func (w *Writer) run() {
+	defer close(w.stopped)
+
+	backoff := w.settings.MinBackoff
+	var conn net.Conn
+	defer func() {
+		if conn != nil {
+			_ = conn.Close()
+		}
+	}()
+
+	// unconfirmed is the number of bytes written to the stream
+	// connection since it last proved healthy, which are counted
+	// as dropped if the connection is found broken.
+	unconfirmed := 0
+
+	for {
+		if conn == nil {
+			var err error
+			conn, err = w.dial(w.settings.Network,
+				w.settings.Address, w.settings.DialTimeout)
+			if err != nil {
+				conn = nil
+				timer := time.NewTimer(backoff)
+				select {
+				case <-timer.C:
+				case <-w.done:
+					timer.Stop()
+					return
+				}
+				backoff *= 2
+				if backoff > w.settings.MaxBackoff {
+					backoff = w.settings.MaxBackoff
+				}
+				continue
+			}
+			backoff = w.settings.MinBackoff
+			unconfirmed = 0
+		}
+
+		err := w.flush(conn, &unconfirmed)
+		if err != nil {
+			_ = conn.Close()
+			conn = nil
+			continue
+		}
+
+		select {
+		case <-w.signal:
+		case <-w.done:
+			_ = w.flush(conn, &unconfirmed)
+			return
+		}
+	}
+}
+
+// flush checks the connection is healthy and sends all the
+// buffered chunks to it. On error, the chunks not sent are put
+// back in front of the buffer, and the bytes written since the
+// connection last proved healthy are counted as dropped.
+func (w *Writer) flush(conn net.Conn, unconfirmed *int) (err error) {
+	if w.stream {
+		err = checkConnection(conn)
+		switch {
+		case err == nil:
+			*unconfirmed = 0
+		case errors.Is(err, io.EOF):
+			// the remote end closed the connection gracefully,
+			// after reading all the data sent to it.
+			*unconfirmed = 0
+			return fmt.Errorf("checking connection: %w", err)
+		default:
+			w.drop(unconfirmed)
+			return fmt.Errorf("checking connection: %w", err)
+		}
+	}
+
+	w.mutex.Lock()
+	chunks := w.chunks
+	w.chunks = nil
+	w.mutex.Unlock()
+
+	for i, chunk := range chunks {
+		_ = conn.SetWriteDeadline(time.Now().Add(w.settings.WriteTimeout))
+		_, err = conn.Write(chunk)
+		if err == nil {
+			w.mutex.Lock()
+			w.bufferedBytes -= len(chunk)
+			w.mutex.Unlock()
+			if w.stream {
+				*unconfirmed += len(chunk)
+			}
+			continue
+		}
+
+		// A partially written chunk is put back entirely, so it is
+		// sent again as a whole and not torn across connections.
+		unsent := make([][]byte, len(chunks)-i)
+		copy(unsent, chunks[i:])
+
+		w.mutex.Lock()
+		w.chunks = append(unsent, w.chunks...)
+		w.mutex.Unlock()
+		w.drop(unconfirmed)
+
+		return fmt.Errorf("writing to connection: %w", err)
+	}
+
+	return nil
+}
+
+// drop counts the unconfirmed bytes as dropped.
+func (w *Writer) drop(unconfirmed *int) {
+	w.mutex.Lock()
+	w.droppedBytes += uint64(*unconfirmed)
+	w.mutex.Unlock()
+	*unconfirmed = 0
+}
+
+// checkConnection returns an error if the stream connection
+// is closed by the remote end or broken. The connection is
+// only used to send data, so data received is discarded.
+func checkConnection(conn net.Conn) (err error) {
+	// A deadline in the past times out without reading,
+	// so a short deadline in the future is used.
+	const checkTimeout = time.Millisecond
+	_ = conn.SetReadDeadline(time.Now().Add(checkTimeout))
+	buffer := make([]byte, 1)
+	_, err = conn.Read(buffer)
+	var netErr net.Error
+	if err == nil || errors.As(err, &netErr) && netErr.Timeout() {
+		return nil
+	}
+	return err
+}
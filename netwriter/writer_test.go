@@ -0,0 +1,332 @@
+package netwriter
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// server is a local TCP server sending each line
+// received to its lines channel.
+type server struct {
+	listener net.Listener
+	lines    chan string
+	conns    chan net.Conn
+}
+
+func newServer(t *testing.T, address string) *server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", address)
+	require.NoError(t, err)
+
+	s := &server{
+		listener: listener,
+		lines:    make(chan string),
+		conns:    make(chan net.Conn, 1),
+	}
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		s.conns <- conn
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			s.lines <- scanner.Text()
+		}
+	}()
+
+	return s
+}
+
+// stop closes the listener and any accepted connection.
+func (s *server) stop() {
+	_ = s.listener.Close()
+	select {
+	case conn := <-s.conns:
+		_ = conn.Close()
+	default:
+	}
+}
+
+func receive(t *testing.T, lines <-chan string) string {
+	t.Helper()
+
+	select {
+	case line := <-lines:
+		return line
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for line")
+		return ""
+	}
+}
+
+func Test_Writer_reconnect(t *testing.T) {
+	t.Parallel()
+
+	srv := newServer(t, "127.0.0.1:0")
+	address := srv.listener.Addr().String()
+
+	writer, err := New(Settings{
+		Address:    address,
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer writer.Close()
+
+	_, err = writer.Write([]byte("before restart\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "before restart", receive(t, srv.lines))
+
+	// restart the server on the same address
+	srv.stop()
+	srv = newServer(t, address)
+	defer srv.stop()
+
+	// Writes to the connection closed by the previous listener
+	// may succeed until the connection is detected as broken,
+	// so keep writing until a line is received. Lines written
+	// before the first line received are lost, and must be
+	// counted as dropped.
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(5 * time.Second)
+	for i := 0; ; i++ {
+		line := "after restart " + strconv.Itoa(i) + "\n"
+		_, err = writer.Write([]byte(line))
+		require.NoError(t, err)
+
+		select {
+		case received := <-srv.lines:
+			assert.Regexp(t, "^after restart [0-9]+$", received)
+			lostLines, err := strconv.Atoi(strings.TrimPrefix(received, "after restart "))
+			require.NoError(t, err)
+			lostBytes := 0
+			for j := 0; j < lostLines; j++ {
+				lostBytes += len("after restart " + strconv.Itoa(j) + "\n")
+			}
+			assert.Equal(t, uint64(lostBytes), writer.DroppedBytes())
+			return
+		case <-timeout:
+			t.Fatal("timeout waiting for line after restart")
+		case <-ticker.C:
+		}
+	}
+}
+
+// testConn is a fake stream connection recording the data
+// written to it.
+type testConn struct {
+	net.Conn // only the methods below are implemented
+
+	mutex   sync.Mutex
+	written string
+	// failNextWrite makes the next write partially write
+	// its data and fail.
+	failNextWrite bool
+	// breakOnNextWrite makes the next write succeed without
+	// the data being received, and breaks the connection.
+	breakOnNextWrite bool
+	broken           bool
+}
+
+var errTestBroken = errors.New("test connection broken")
+
+func (c *testConn) Write(p []byte) (n int, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	switch {
+	case c.failNextWrite:
+		c.failNextWrite = false
+		const partial = 3
+		c.written += string(p[:partial])
+		return partial, errTestBroken
+	case c.breakOnNextWrite:
+		c.breakOnNextWrite = false
+		c.broken = true
+		return len(p), nil
+	}
+	c.written += string(p)
+	return len(p), nil
+}
+
+func (c *testConn) Read([]byte) (n int, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.broken {
+		return 0, errTestBroken
+	}
+	return 0, os.ErrDeadlineExceeded
+}
+
+func (c *testConn) getWritten() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.written
+}
+
+func (c *testConn) SetReadDeadline(time.Time) error  { return nil }
+func (c *testConn) SetWriteDeadline(time.Time) error { return nil }
+func (c *testConn) Close() error                     { return nil }
+
+func newTestDial(conns ...*testConn) dialFunc {
+	var mutex sync.Mutex
+	return func(network, address string, timeout time.Duration) (net.Conn, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if len(conns) == 0 {
+			return nil, errTestBroken
+		}
+		conn := conns[0]
+		conns = conns[1:]
+		return conn, nil
+	}
+}
+
+func Test_Writer_partialWrite(t *testing.T) {
+	t.Parallel()
+
+	connA := &testConn{failNextWrite: true}
+	connB := &testConn{}
+	writer, err := newWriter(Settings{Address: "test", MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond}, newTestDial(connA, connB))
+	require.NoError(t, err)
+	defer writer.Close()
+
+	_, err = writer.Write([]byte("hello\n"))
+	require.NoError(t, err)
+
+	// the line is sent again as a whole on the new connection
+	assert.Eventually(t, func() bool {
+		return connB.getWritten() == "hello\n"
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, "hel", connA.getWritten())
+	assert.Zero(t, writer.DroppedBytes())
+}
+
+func Test_Writer_brokenConnection(t *testing.T) {
+	t.Parallel()
+
+	connA := &testConn{}
+	connB := &testConn{}
+	writer, err := newWriter(Settings{Address: "test", MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond}, newTestDial(connA, connB))
+	require.NoError(t, err)
+	defer writer.Close()
+
+	_, err = writer.Write([]byte("a\n"))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return connA.getWritten() == "a\n"
+	}, 5*time.Second, time.Millisecond)
+
+	// the line written is lost since the connection breaks
+	connA.mutex.Lock()
+	connA.breakOnNextWrite = true
+	connA.mutex.Unlock()
+	_, err = writer.Write([]byte("bc\n"))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		connA.mutex.Lock()
+		defer connA.mutex.Unlock()
+		return connA.broken
+	}, 5*time.Second, time.Millisecond)
+
+	_, err = writer.Write([]byte("d\n"))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return connB.getWritten() == "d\n"
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, uint64(len("bc\n")), writer.DroppedBytes())
+	assert.Equal(t, "a\n", connA.getWritten())
+}
+
+func Test_Writer_buffer(t *testing.T) {
+	t.Parallel()
+
+	// reserve an address with nothing listening on it
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	err = listener.Close()
+	require.NoError(t, err)
+
+	writer, err := New(Settings{
+		Address:    address,
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+		BufferSize: 10,
+	})
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("12345\n"))
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("6789\n"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), writer.DroppedBytes())
+
+	// the server comes up and receives buffered data
+	srv := newServer(t, address)
+	defer srv.stop()
+
+	assert.Equal(t, "12345", receive(t, srv.lines))
+
+	err = writer.Close()
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("closed\n"))
+	assert.ErrorIs(t, err, ErrWriterClosed)
+}
+
+func Test_Writer_UDP(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	writer, err := New(Settings{
+		Network: "udp",
+		Address: listener.LocalAddr().String(),
+	})
+	require.NoError(t, err)
+	defer writer.Close()
+
+	_, err = writer.Write([]byte("first"))
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("second"))
+	require.NoError(t, err)
+
+	buffer := make([]byte, 1024)
+	_ = listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for _, expected := range []string{"first", "second"} {
+		n, _, err := listener.ReadFrom(buffer)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(buffer[:n]))
+	}
+}
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Settings{})
+	assert.ErrorIs(t, err, ErrAddressNotSet)
+
+	_, err = New(Settings{Network: "ip", Address: "127.0.0.1:1"})
+	assert.ErrorIs(t, err, ErrNetworkNotSupported)
+
+	_, err = New(Settings{Address: "127.0.0.1:1", MinBackoff: time.Second, MaxBackoff: time.Millisecond})
+	assert.ErrorIs(t, err, ErrBackoffNotValid)
+}
@@ -2,6 +2,12 @@ package log
 
 import (
 	"io"
+	"regexp"
+	"time"
+
+	"github.com/qdm12/log/internal/caller"
+	"github.com/qdm12/log/internal/dedup"
+	"github.com/qdm12/log/internal/sampling"
 )
 
 // Option is the type to specify settings modifier
@@ -16,6 +22,19 @@ func SetLevel(level Level) Option {
 	}
 }
 
+// SetLevelSpec sets a level specification, created with
+// ParseLevelSpec, from which the logger level is resolved using
+// its component. If the specification has no entry matching the
+// component and no default level, the level set with SetLevel
+// is used. The level specification is shared with child loggers,
+// such that updating it at runtime updates all of them.
+// The default is no level specification.
+func SetLevelSpec(levelSpec *LevelSpec) Option {
+	return func(s *settings) {
+		s.levelSpec = levelSpec
+	}
+}
+
 // SetComponent sets the component for the logger
 // which will be logged on every log operation.
 // Set it to the empty string so no component is logged.
@@ -23,6 +42,39 @@ func SetLevel(level Level) Option {
 func SetComponent(component string) Option {
 	return func(s *settings) {
 		s.component = component
+		s.componentAppend = false
+	}
+}
+
+// AppendComponent appends the component given to the existing
+// component of the logger, to build a component path such as
+// "api/auth/jwt" for child loggers. If the logger has no
+// component, the component given is used as is.
+// The component separator can be set with SetComponentSeparator.
+func AppendComponent(component string) Option {
+	return func(s *settings) {
+		if s.component == "" {
+			// the parent component may not be known yet, for example
+			// for options given to the New method of a logger.
+			s.component = component
+			s.componentAppend = true
+			return
+		}
+
+		separator := defaultComponentSeparator
+		if s.componentSeparator != nil {
+			separator = *s.componentSeparator
+		}
+		s.component = joinComponents(s.component, component, separator)
+	}
+}
+
+// SetComponentSeparator sets the separator used to join
+// components with the AppendComponent option.
+// The default is "/".
+func SetComponentSeparator(separator string) Option {
+	return func(s *settings) {
+		s.componentSeparator = &separator
 	}
 }
 
@@ -50,6 +102,37 @@ func SetCallerFunc(enabled bool) Option {
 	}
 }
 
+// SetCallerPathMode sets how the caller file path is displayed,
+// when the caller file is logged.
+// The default is CallerPathBase.
+func SetCallerPathMode(mode CallerPathMode) Option {
+	return func(s *settings) {
+		pathMode := caller.PathMode(mode)
+		s.caller.PathMode = &pathMode
+	}
+}
+
+// SetCallerFuncMode sets how the caller function name is displayed,
+// when the caller function is logged.
+// The default is CallerFuncShort.
+func SetCallerFuncMode(mode CallerFuncMode) Option {
+	return func(s *settings) {
+		funcMode := caller.FuncMode(mode)
+		s.caller.FuncMode = &funcMode
+	}
+}
+
+// AddCallerSkip increases the number of stack frames to skip
+// when determining the caller, which is useful when wrapping the
+// logger methods in your own functions. The skip value is added
+// to the skip value inherited from the parent logger.
+// You may also use Helper to mark your wrapping functions.
+func AddCallerSkip(skip uint) Option {
+	return func(s *settings) {
+		s.caller.Skip += skip
+	}
+}
+
 // SetTimeFormat set the time format for the logger.
 // You can set it to an empty string in order to not
 // log the time.
@@ -60,6 +143,79 @@ func SetTimeFormat(timeFormat string) Option {
 	}
 }
 
+// SetTimestampMode sets how the time of each record is rendered,
+// for example as a Unix epoch in milliseconds or as the duration
+// elapsed since the process start.
+// The default is TimestampLayout, which uses the time format
+// set with SetTimeFormat.
+func SetTimestampMode(mode TimestampMode) Option {
+	return func(s *settings) {
+		s.timestampMode = &mode
+	}
+}
+
+// SetTimeZone sets the time zone to use for the log times,
+// for example time.UTC, time.Local or a location obtained
+// with time.LoadLocation. A nil location is ignored.
+// The default is to use the time zone of the clock, which is
+// the local time zone for the default clock.
+func SetTimeZone(location *time.Location) Option {
+	return func(s *settings) {
+		if location == nil {
+			return
+		}
+		s.timeZone = location
+	}
+}
+
+// SetClock sets the function used by the logger to get the
+// current time, which is notably useful for deterministic tests.
+// The clock time when the option is applied, for example when the
+// logger is created, is the start time for TimestampElapsed.
+// A nil clock function is ignored.
+// The default clock is time.Now.
+func SetClock(clock func() time.Time) Option {
+	return func(s *settings) {
+		if clock == nil {
+			return
+		}
+		s.clock = clock
+		s.start = clock()
+	}
+}
+
+// SetMultiline sets how messages spanning multiple lines
+// are rendered: as they are, with continuation lines indented
+// under the message, with each continuation line prefixed with
+// the time, level and component, or with new lines escaped.
+// The default is MultilineRaw, writing the message as it is.
+func SetMultiline(mode MultilineMode) Option {
+	return func(s *settings) {
+		s.multiline = &mode
+	}
+}
+
+// SetTheme sets the theme defining the level labels and the
+// colors used to render log lines. The theme is copied so it
+// can be modified safely after this call.
+// The default is the theme returned by DefaultTheme.
+func SetTheme(theme Theme) Option {
+	return func(s *settings) {
+		theme = theme.copy()
+		s.theme = &theme
+	}
+}
+
+// SetAlignment sets the alignment of the columns of log lines.
+// Learned widths are shared with the child loggers created from
+// the logger.
+// The default is no alignment.
+func SetAlignment(alignment Alignment) Option {
+	return func(s *settings) {
+		s.aligner = newAligner(alignment)
+	}
+}
+
 // SetWriters sets the writers for the logger.
 // The writers defaults to a single writer of os.Stdout.
 func SetWriters(writers ...io.Writer) Option {
@@ -92,3 +248,153 @@ func AddWriters(writers ...io.Writer) Option {
 		s.writers = newWriters
 	}
 }
+
+// RemoveWriters removes the writers given from the writers
+// of the logger, for example to detach a writer previously
+// added with AddWriters using the Patch method. Writers not
+// used by the logger are ignored, and writers removed are
+// not closed.
+func RemoveWriters(writers ...io.Writer) Option {
+	return func(s *settings) {
+		s.writers = removeWriters(s.writers, writers)
+		s.removedWriters = append(s.removedWriters, writers...)
+	}
+}
+
+// SetSampling enables sampling of log records, for each message
+// template and level. In each interval, the first records are
+// logged and then only every thereafter-th record is logged.
+// If thereafter is 0, no more record is logged for that message
+// template and level until the end of the interval.
+// The sampling state is shared with child loggers created with
+// the New method, unless they are given this option again.
+// The default is no sampling.
+func SetSampling(first, thereafter uint, interval time.Duration) Option {
+	return func(s *settings) {
+		s.sampler = sampling.New(first, thereafter, interval)
+	}
+}
+
+// SetDeduplication enables the suppression of consecutive
+// identical records, that is with the same level, component
+// and message, within the time window given starting from the
+// first record of the series. A single "last message repeated N
+// times" record is logged when the window ends or when a
+// different record is logged. Call the FlushDuplicates method
+// before exiting to log any pending summary.
+// The deduplication state is shared with child loggers created
+// with the New method, unless they are given this option again.
+// The default is no deduplication.
+func SetDeduplication(window time.Duration) Option {
+	return func(s *settings) {
+		s.deduplicator = dedup.New(window)
+	}
+}
+
+// AddHooks adds hooks to run, in order, on each record before
+// it gets formatted and written. Hooks are inherited by child
+// loggers, and hooks given to a child logger are run after
+// the hooks inherited from its parent.
+// The default is no hook.
+func AddHooks(hooks ...Hook) Option {
+	return func(s *settings) {
+		newHooks := make([]Hook, 0, len(s.hooks)+len(hooks))
+		newHooks = append(newHooks, s.hooks...)
+		s.hooks = append(newHooks, hooks...)
+	}
+}
+
+// RedactKeys masks the values of fields with one of the keys
+// given, as well as values following these keys in messages,
+// such as `password=value` or `password: value`.
+// Keys are matched case insensitively.
+// Redaction rules are inherited by child loggers, and rules
+// given to a child logger are added to the inherited ones.
+// The default is no redaction.
+func RedactKeys(keys ...string) Option {
+	return func(s *settings) {
+		if len(keys) == 0 {
+			return
+		}
+		redactedKeys := make([]string, 0, len(s.redactedKeys)+len(keys))
+		redactedKeys = append(redactedKeys, s.redactedKeys...)
+		s.redactedKeys = append(redactedKeys, keys...)
+		addRedactors(s, newKeysRedactor(keys))
+	}
+}
+
+// RedactPatterns masks every match of the regular expressions
+// given in messages and field values.
+// Redaction rules are inherited by child loggers, and rules
+// given to a child logger are added to the inherited ones.
+// The default is no redaction.
+func RedactPatterns(patterns ...*regexp.Regexp) Option {
+	return func(s *settings) {
+		redactors := make([]Redactor, len(patterns))
+		for i, pattern := range patterns {
+			redactors[i] = NewPatternRedactor(pattern)
+		}
+		addRedactors(s, redactors...)
+	}
+}
+
+// AddRedactors adds redactors to run, in order, on messages
+// and field values. Built-in redactors are available with
+// JWTRedactor, BearerTokenRedactor, AWSKeyRedactor and
+// CreditCardRedactor.
+// Redaction rules are inherited by child loggers, and rules
+// given to a child logger are added to the inherited ones.
+// The default is no redaction.
+func AddRedactors(redactors ...Redactor) Option {
+	return func(s *settings) {
+		addRedactors(s, redactors...)
+	}
+}
+
+func addRedactors(s *settings, redactors ...Redactor) {
+	newRedactors := make([]Redactor, 0, len(s.redactors)+len(redactors))
+	newRedactors = append(newRedactors, s.redactors...)
+	s.redactors = append(newRedactors, redactors...)
+}
+
+// AddContextExtractors adds extractors of fields from the context
+// given to the context-aware log methods such as InfoContext.
+// Extractors are inherited by child loggers, and extractors given
+// to a child logger are run after the inherited ones.
+// The default is no context extractor.
+func AddContextExtractors(extractors ...ContextExtractor) Option {
+	return func(s *settings) {
+		newExtractors := make([]ContextExtractor, 0, len(s.contextExtractors)+len(extractors))
+		newExtractors = append(newExtractors, s.contextExtractors...)
+		s.contextExtractors = append(newExtractors, extractors...)
+	}
+}
+
+// OnWriteError sets a handler called when writing a line to
+// a writer fails, for example because the disk is full or the
+// pipe is closed. You can use FallbackToStderr to write such
+// lines to os.Stderr instead. The handler is called once the
+// logger is unlocked, so it can use the logger, for example to
+// patch it with RemoveWriters. A nil handler is ignored.
+// The default is to ignore write errors.
+func OnWriteError(handler WriteErrorHandler) Option {
+	return func(s *settings) {
+		if handler == nil {
+			return
+		}
+		s.writeErrorHandler = handler
+	}
+}
+
+// DisableFailingWriters disables writers failing to write
+// maxFailures times in a row, and retries writing to them
+// once every retryPeriod. Lines not written to a disabled
+// writer are given to the write error handler with the error
+// ErrWriterDisabled. The state of writers is shared with the
+// child loggers created from the logger.
+// The default is to never disable writers.
+func DisableFailingWriters(maxFailures uint, retryPeriod time.Duration) Option {
+	return func(s *settings) {
+		s.writerBreaker = newWriterBreaker(maxFailures, retryPeriod)
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/qdm12/log/internal/caller"
+	"github.com/qdm12/log/internal/dedup"
+	"github.com/qdm12/log/internal/sampling"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,6 +27,12 @@ func Test_Option(t *testing.T) {
 				level: levelPtr(LevelInfo),
 			},
 		},
+		"SetLevelSpec": {
+			option: SetLevelSpec(&LevelSpec{spec: "info"}),
+			expectedSettings: settings{
+				levelSpec: &LevelSpec{spec: "info"},
+			},
+		},
 		"SetCallerFile": {
 			option: SetCallerFile(false),
 			expectedSettings: settings{
@@ -48,18 +57,139 @@ func Test_Option(t *testing.T) {
 				},
 			},
 		},
+		"SetCallerPathMode": {
+			option: SetCallerPathMode(CallerPathModule),
+			expectedSettings: settings{
+				caller: caller.Settings{
+					PathMode: func() *caller.PathMode {
+						mode := caller.PathModule
+						return &mode
+					}(),
+				},
+			},
+		},
+		"SetCallerFuncMode": {
+			option: SetCallerFuncMode(CallerFuncPackage),
+			expectedSettings: settings{
+				caller: caller.Settings{
+					FuncMode: func() *caller.FuncMode {
+						mode := caller.FuncPackage
+						return &mode
+					}(),
+				},
+			},
+		},
+		"AddCallerSkip": {
+			initialSettings: settings{
+				caller: caller.Settings{Skip: 1},
+			},
+			option: AddCallerSkip(2),
+			expectedSettings: settings{
+				caller: caller.Settings{Skip: 3},
+			},
+		},
+		"SetTimestampMode": {
+			option: SetTimestampMode(TimestampElapsed),
+			expectedSettings: settings{
+				timestampMode: timestampModePtr(TimestampElapsed),
+			},
+		},
+		"SetTheme": {
+			option: SetTheme(Theme{PadLevels: true}),
+			expectedSettings: settings{
+				theme: &Theme{PadLevels: true},
+			},
+		},
+		"SetAlignment": {
+			option: SetAlignment(Alignment{ComponentWidth: 5}),
+			expectedSettings: settings{
+				aligner: &aligner{settings: Alignment{ComponentWidth: 5}},
+			},
+		},
+		"DisableFailingWriters": {
+			option: DisableFailingWriters(3, time.Second),
+			expectedSettings: settings{
+				writerBreaker: newWriterBreaker(3, time.Second),
+			},
+		},
+		"SetTimeZone": {
+			option: SetTimeZone(time.UTC),
+			expectedSettings: settings{
+				timeZone: time.UTC,
+			},
+		},
+		"SetTimeZone nil": {
+			initialSettings: settings{
+				timeZone: time.UTC,
+			},
+			option: SetTimeZone(nil),
+			expectedSettings: settings{
+				timeZone: time.UTC,
+			},
+		},
 		"SetTimeFormat": {
 			option: SetTimeFormat("123"),
 			expectedSettings: settings{
 				timeFormat: stringPtr("123"),
 			},
 		},
+		"AppendComponent without component": {
+			option: AppendComponent("b"),
+			expectedSettings: settings{
+				component:       "b",
+				componentAppend: true,
+			},
+		},
+		"AppendComponent with component": {
+			initialSettings: settings{
+				component:          "a",
+				componentSeparator: stringPtr("."),
+			},
+			option: AppendComponent("b"),
+			expectedSettings: settings{
+				component:          "a.b",
+				componentSeparator: stringPtr("."),
+			},
+		},
+		"SetComponentSeparator": {
+			option: SetComponentSeparator("."),
+			expectedSettings: settings{
+				componentSeparator: stringPtr("."),
+			},
+		},
+		"SetMultiline": {
+			option: SetMultiline(MultilineEscape),
+			expectedSettings: settings{
+				multiline: multilinePtr(MultilineEscape),
+			},
+		},
 		"SetWriters": {
 			option: SetWriters(os.Stdout, io.Discard),
 			expectedSettings: settings{
 				writers: []io.Writer{os.Stdout, io.Discard},
 			},
 		},
+		"SetSampling": {
+			option: SetSampling(1, 2, time.Second),
+			expectedSettings: settings{
+				sampler: sampling.New(1, 2, time.Second),
+			},
+		},
+		"SetDeduplication": {
+			option: SetDeduplication(time.Second),
+			expectedSettings: settings{
+				deduplicator: dedup.New(time.Second),
+			},
+		},
+		"AddHooks": {
+			initialSettings: settings{
+				hooks: []Hook{testFieldHook{key: "a"}},
+			},
+			option: AddHooks(testFieldHook{key: "b"}),
+			expectedSettings: settings{
+				hooks: []Hook{testFieldHook{key: "a"}, testFieldHook{key: "b"}},
+			},
+		},
 		"AddWriters": {
 			initialSettings: settings{
 				writers: []io.Writer{bytes.NewBuffer(nil), io.Discard},
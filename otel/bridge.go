@@ -0,0 +1,65 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/qdm12/log"
+)
+
+var _ log.RecordWriter = (*Bridge)(nil)
+
+// Bridge is a writer converting log records to OpenTelemetry
+// records and exporting them with an exporter. It can be given
+// to a logger with the log.SetWriters or log.AddWriters options.
+// It is thread safe to use if its exporter is thread safe.
+type Bridge struct {
+	exporter Exporter
+	timeout  time.Duration
+}
+
+// NewBridge creates a bridge exporting each record with the
+// exporter given, with the timeout given for each export.
+// A zero timeout means no timeout.
+func NewBridge(exporter Exporter, timeout time.Duration) *Bridge {
+	return &Bridge{
+		exporter: exporter,
+		timeout:  timeout,
+	}
+}
+
+// WriteRecord converts and exports the record.
+func (b *Bridge) WriteRecord(record log.Record) (err error) {
+	converted := convert(record, time.Now())
+
+	ctx := context.Background()
+	if b.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.timeout)
+		defer cancel()
+	}
+
+	err = b.exporter.Export(ctx, []Record{converted})
+	if err != nil {
+		return fmt.Errorf("exporting record: %w", err)
+	}
+	return nil
+}
+
+// Write exports the data given as a record at the
+// info level, with any trailing new line removed.
+func (b *Bridge) Write(p []byte) (n int, err error) {
+	record := log.Record{
+		Level:   log.LevelInfo,
+		Time:    time.Now(),
+		Message: strings.TrimSuffix(string(p), "\n"),
+	}
+
+	err = b.WriteRecord(record)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
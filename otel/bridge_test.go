@@ -0,0 +1,83 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/qdm12/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_Bridge(t *testing.T) {
+	t.Parallel()
+
+	exporter := NewInMemoryExporter()
+	bridge := NewBridge(exporter, 0)
+
+	logger := log.New(
+		log.SetWriters(bridge),
+		log.SetComponent("http"),
+		log.AddContextExtractors(ExtractTrace),
+	)
+
+	spanContext := newSpanContext()
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+	logger.WarnContext(ctx, "request %d failed", 1)
+	logger.Info("no span")
+
+	records := exporter.Records()
+	require.Len(t, records, 2)
+
+	record := records[0]
+	assert.Equal(t, SeverityWarn, record.SeverityNumber)
+	assert.Equal(t, "WARN", record.SeverityText)
+	assert.Equal(t, "request 1 failed", record.Body)
+	assert.Equal(t, spanContext.TraceID(), record.TraceID)
+	assert.Equal(t, spanContext.SpanID(), record.SpanID)
+	assert.Equal(t, trace.FlagsSampled, record.TraceFlags)
+	assert.Equal(t, []Attribute{{Key: ComponentKey, Value: "http"}}, record.Attributes)
+	assert.False(t, record.Timestamp.IsZero())
+	assert.False(t, record.ObservedTimestamp.IsZero())
+
+	record = records[1]
+	assert.Equal(t, SeverityInfo, record.SeverityNumber)
+	assert.Equal(t, "no span", record.Body)
+	assert.False(t, record.TraceID.IsValid())
+
+	exporter.Reset()
+	assert.Empty(t, exporter.Records())
+}
+
+func Test_convert(t *testing.T) {
+	t.Parallel()
+
+	record := log.Record{
+		Level: log.LevelDebug,
+		Caller: log.Caller{
+			File:     "/a/main.go",
+			Line:     12,
+			Function: "main.main",
+		},
+		Fields: []log.Field{
+			{Key: TraceIDKey, Value: "invalid"},
+			{Key: "user", Value: "john"},
+		},
+	}
+
+	converted := convert(record, record.Time)
+
+	expected := Record{
+		SeverityNumber: SeverityDebug,
+		SeverityText:   "DEBUG",
+		Attributes: []Attribute{
+			{Key: CodeFilepathKey, Value: "/a/main.go"},
+			{Key: CodeLinenoKey, Value: 12},
+			{Key: CodeFunctionKey, Value: "main.main"},
+			{Key: TraceIDKey, Value: "invalid"},
+			{Key: "user", Value: "john"},
+		},
+	}
+	assert.Equal(t, expected, converted)
+}
@@ -0,0 +1,48 @@
+package otel
+
+import (
+	"context"
+	"sync"
+)
+
+// Exporter is the interface to export OpenTelemetry records,
+// for example to an OTLP collector.
+type Exporter interface {
+	Export(ctx context.Context, records []Record) error
+}
+
+// InMemoryExporter is an exporter keeping records in memory,
+// which is useful for testing. It is thread safe to use.
+type InMemoryExporter struct {
+	mutex   sync.Mutex
+	records []Record
+}
+
+// NewInMemoryExporter creates a new in-memory exporter.
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{}
+}
+
+// Export stores the records in memory.
+func (e *InMemoryExporter) Export(_ context.Context, records []Record) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.records = append(e.records, records...)
+	return nil
+}
+
+// Records returns a copy of the records exported so far.
+func (e *InMemoryExporter) Records() (records []Record) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	records = make([]Record, len(e.records))
+	copy(records, e.records)
+	return records
+}
+
+// Reset removes all the records stored.
+func (e *InMemoryExporter) Reset() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.records = nil
+}
@@ -0,0 +1,34 @@
+package otel
+
+import (
+	"context"
+
+	"github.com/qdm12/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Field keys used for the trace correlation fields.
+const (
+	TraceIDKey    = "trace_id"
+	SpanIDKey     = "span_id"
+	TraceFlagsKey = "trace_flags"
+)
+
+var _ log.ContextExtractor = ExtractTrace
+
+// ExtractTrace extracts the trace ID, span ID and trace flags
+// of the active span in the context as fields, and returns no
+// field if the context has no valid span context.
+// It is meant to be used with the log.AddContextExtractors option.
+func ExtractTrace(ctx context.Context) (fields []log.Field) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	return []log.Field{
+		{Key: TraceIDKey, Value: spanContext.TraceID().String()},
+		{Key: SpanIDKey, Value: spanContext.SpanID().String()},
+		{Key: TraceFlagsKey, Value: spanContext.TraceFlags().String()},
+	}
+}
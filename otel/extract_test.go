@@ -0,0 +1,52 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/qdm12/log"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newSpanContext() trace.SpanContext {
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func Test_ExtractTrace(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		ctx    context.Context //nolint:containedctx
+		fields []log.Field
+	}{
+		"no span": {
+			ctx: context.Background(),
+		},
+		"active span": {
+			ctx: trace.ContextWithSpanContext(context.Background(), newSpanContext()),
+			fields: []log.Field{
+				{Key: TraceIDKey, Value: "0102030405060708090a0b0c0d0e0f10"},
+				{Key: SpanIDKey, Value: "0102030405060708"},
+				{Key: TraceFlagsKey, Value: "01"},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fields := ExtractTrace(testCase.ctx)
+
+			assert.Equal(t, testCase.fields, fields)
+		})
+	}
+}
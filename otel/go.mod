@@ -0,0 +1,20 @@
+module github.com/qdm12/log/otel
+
+go 1.17
+
+require (
+	github.com/qdm12/log v0.0.0-20261019155242-076c8a0b9583
+	github.com/stretchr/testify v1.7.1
+	go.opentelemetry.io/otel/trace v1.10.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/fatih/color v1.13.0 // indirect
+	github.com/mattn/go-colorable v0.1.9 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel v1.10.0 // indirect
+	golang.org/x/sys v0.0.0-20210630005230-0f9fa26af87c // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
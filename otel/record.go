@@ -0,0 +1,140 @@
+package otel
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/qdm12/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Severity is the severity number of the OpenTelemetry
+// log data model.
+type Severity int
+
+// Severity numbers of the OpenTelemetry log data model
+// corresponding to the log levels.
+const (
+	SeverityDebug Severity = 5
+	SeverityInfo  Severity = 9
+	SeverityWarn  Severity = 13
+	SeverityError Severity = 17
+)
+
+// LevelToSeverity returns the OpenTelemetry severity number
+// corresponding to the log level given.
+func LevelToSeverity(level log.Level) Severity {
+	switch level {
+	case log.LevelError:
+		return SeverityError
+	case log.LevelWarn:
+		return SeverityWarn
+	case log.LevelInfo:
+		return SeverityInfo
+	case log.LevelDebug:
+		return SeverityDebug
+	default:
+		return 0
+	}
+}
+
+// Record is a log record following the OpenTelemetry
+// log data model.
+type Record struct {
+	Timestamp         time.Time
+	ObservedTimestamp time.Time
+	SeverityNumber    Severity
+	SeverityText      string
+	Body              string
+	Attributes        []Attribute
+	TraceID           trace.TraceID
+	SpanID            trace.SpanID
+	TraceFlags        trace.TraceFlags
+}
+
+// Attribute is a key value attribute of a record.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attribute keys following the OpenTelemetry semantic conventions.
+const (
+	ComponentKey    = "component"
+	CodeFilepathKey = "code.filepath"
+	CodeLinenoKey   = "code.lineno"
+	CodeFunctionKey = "code.function"
+)
+
+// convert converts a log record to an OpenTelemetry record.
+// The trace correlation fields added by ExtractTrace are
+// converted to the record trace ID, span ID and trace flags.
+func convert(record log.Record, observed time.Time) (converted Record) {
+	converted = Record{
+		Timestamp:         record.Time,
+		ObservedTimestamp: observed,
+		SeverityNumber:    LevelToSeverity(record.Level),
+		SeverityText:      record.Level.String(),
+		Body:              record.Message,
+	}
+
+	if record.Component != "" {
+		converted.Attributes = append(converted.Attributes,
+			Attribute{Key: ComponentKey, Value: record.Component})
+	}
+
+	if record.Caller.File != "" {
+		converted.Attributes = append(converted.Attributes,
+			Attribute{Key: CodeFilepathKey, Value: record.Caller.File},
+			Attribute{Key: CodeLinenoKey, Value: record.Caller.Line})
+		if record.Caller.Function != "" {
+			converted.Attributes = append(converted.Attributes,
+				Attribute{Key: CodeFunctionKey, Value: record.Caller.Function})
+		}
+	}
+
+	for _, field := range record.Fields {
+		if setTraceField(&converted, field) {
+			continue
+		}
+		converted.Attributes = append(converted.Attributes,
+			Attribute{Key: field.Key, Value: field.Value})
+	}
+
+	return converted
+}
+
+// setTraceField sets the trace ID, span ID or trace flags of the
+// record if the field is one of the trace correlation fields with
+// a valid value, and returns true if it was set.
+func setTraceField(record *Record, field log.Field) (set bool) {
+	value, ok := field.Value.(string)
+	if !ok {
+		return false
+	}
+
+	switch field.Key {
+	case TraceIDKey:
+		traceID, err := trace.TraceIDFromHex(value)
+		if err != nil {
+			return false
+		}
+		record.TraceID = traceID
+	case SpanIDKey:
+		spanID, err := trace.SpanIDFromHex(value)
+		if err != nil {
+			return false
+		}
+		record.SpanID = spanID
+	case TraceFlagsKey:
+		b, err := hex.DecodeString(value)
+		if err != nil || len(b) != 1 {
+			return false
+		}
+		record.TraceFlags = trace.TraceFlags(b[0])
+	default:
+		return false
+	}
+
+	return true
+}
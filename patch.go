@@ -1,8 +1,17 @@
 package log
 
+import "io"
+
 // Patch patches the existing settings with any option given.
 // This is thread safe but does not propagates to child loggers.
 func (l *Logger) Patch(options ...Option) {
+	if l.members != nil {
+		for _, member := range l.members {
+			member.Patch(options...)
+		}
+		return
+	}
+
 	l.settingsMutex.Lock()
 	defer l.settingsMutex.Unlock()
 
@@ -10,11 +19,30 @@ func (l *Logger) Patch(options ...Option) {
 	for _, option := range options {
 		option(&updatedSettings)
 	}
+	// writers to remove are already removed from the writers
+	updatedSettings.removedWriters = nil
 
 	writerMutexes := writersRegistry.RegisterWriters(updatedSettings.writers)
+	writersRegistry.ReleaseWriters(l.settings.writers)
 
+	l.ownedWriters = patchOwnedWriters(l.ownedWriters,
+		l.settings.writers, updatedSettings.writers)
 	l.settings = updatedSettings
 	l.writersMutexesMutex.Lock()
 	l.writersMutexes = writerMutexes
 	l.writersMutexesMutex.Unlock()
 }
+
+// patchOwnedWriters returns the writers owned by a logger
+// patched from the old writers to the new writers given.
+// New writers are owned by the logger, and owned writers
+// no longer used by the logger are no longer owned.
+func patchOwnedWriters(owned, oldWriters, newWriters []io.Writer) (
+	patchedOwned []io.Writer) {
+	for _, writer := range newWriters {
+		if containsWriter(owned, writer) || !containsWriter(oldWriters, writer) {
+			patchedOwned = append(patchedOwned, writer)
+		}
+	}
+	return patchedOwned
+}
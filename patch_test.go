@@ -139,3 +139,20 @@ func Test_Logger_patch(t *testing.T) {
 		})
 	}
 }
+
+func Test_patchOwnedWriters(t *testing.T) {
+	t.Parallel()
+
+	owned := &testToggleWriter{lines: []string{"owned"}}
+	inherited := &testToggleWriter{lines: []string{"inherited"}}
+	added := &testToggleWriter{lines: []string{"added"}}
+	removed := &testToggleWriter{lines: []string{"removed"}}
+
+	patchedOwned := patchOwnedWriters(
+		[]io.Writer{owned, removed},
+		[]io.Writer{owned, inherited, removed},
+		[]io.Writer{owned, inherited, added},
+	)
+
+	assert.Equal(t, []io.Writer{owned, added}, patchedOwned)
+}
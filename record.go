@@ -0,0 +1,73 @@
+package log
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qdm12/log/internal/caller"
+)
+
+// Record is a log record, built for each log method call
+// before being formatted and written to the writers.
+type Record struct {
+	Level     Level
+	Time      time.Time
+	Component string
+	Message   string
+	// Caller is the caller of the log method. It is only
+	// set if at least one of the caller file, line or function
+	// is enabled with the SetCallerFile, SetCallerLine and
+	// SetCallerFunc options.
+	Caller Caller
+	Fields []Field
+}
+
+// Caller contains information on the caller of a log method.
+type Caller struct {
+	// File is the full file path of the caller.
+	File string
+	// Line is the line number of the caller.
+	Line int
+	// Function is the fully qualified function name of the caller.
+	Function string
+}
+
+func (c Caller) toFrame() caller.Frame {
+	return caller.Frame{
+		File:     c.File,
+		Line:     c.Line,
+		Function: c.Function,
+	}
+}
+
+func callerFromFrame(frame caller.Frame) Caller {
+	return Caller{
+		File:     frame.File,
+		Line:     frame.Line,
+		Function: frame.Function,
+	}
+}
+
+// Field is a key value pair attached to a record.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns the field in the format key=value,
+// with the value quoted if needed.
+func (f Field) String() string {
+	return f.Key + "=" + f.valueString()
+}
+
+// valueString returns the field value as a string,
+// quoted if needed.
+func (f Field) valueString() string {
+	value := fmt.Sprint(f.Value)
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		value = strconv.Quote(value)
+	}
+	return value
+}
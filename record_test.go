@@ -0,0 +1,44 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Field_String(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		field Field
+		s     string
+	}{
+		"string": {
+			field: Field{Key: "key", Value: "value"},
+			s:     "key=value",
+		},
+		"integer": {
+			field: Field{Key: "key", Value: 1},
+			s:     "key=1",
+		},
+		"empty string": {
+			field: Field{Key: "key", Value: ""},
+			s:     `key=""`,
+		},
+		"string with space": {
+			field: Field{Key: "key", Value: "a b"},
+			s:     `key="a b"`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := testCase.field.String()
+
+			assert.Equal(t, testCase.s, s)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package log
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RedactedMask is the string replacing redacted secrets.
+const RedactedMask = "[REDACTED]"
+
+// Redactor is the interface to mask secrets in strings.
+type Redactor interface {
+	// Redact returns the string given with its secrets masked.
+	Redact(s string) (redacted string)
+}
+
+// RedactorFunc is a function implementing the Redactor interface.
+type RedactorFunc func(s string) (redacted string)
+
+// Redact calls the redactor function with the string given.
+func (f RedactorFunc) Redact(s string) (redacted string) {
+	return f(s)
+}
+
+// NewPatternRedactor returns a redactor replacing every match
+// of the regular expression given with the redacted mask.
+func NewPatternRedactor(pattern *regexp.Regexp) Redactor {
+	return RedactorFunc(func(s string) string {
+		return pattern.ReplaceAllLiteralString(s, RedactedMask)
+	})
+}
+
+// JWTRedactor returns a redactor masking JSON web tokens.
+func JWTRedactor() Redactor {
+	return NewPatternRedactor(regexp.MustCompile(
+		`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`))
+}
+
+// BearerTokenRedactor returns a redactor masking the token
+// of bearer authorization values.
+func BearerTokenRedactor() Redactor {
+	regex := regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+	return RedactorFunc(func(s string) string {
+		return regex.ReplaceAllString(s, "${1}"+RedactedMask)
+	})
+}
+
+// AWSKeyRedactor returns a redactor masking AWS access key IDs.
+func AWSKeyRedactor() Redactor {
+	return NewPatternRedactor(regexp.MustCompile(
+		`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[0-9A-Z]{16}\b`))
+}
+
+// CreditCardRedactor returns a redactor masking credit card
+// numbers, optionally separated by spaces or dashes, which
+// pass the Luhn checksum.
+func CreditCardRedactor() Redactor {
+	regex := regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	return RedactorFunc(func(s string) string {
+		return regex.ReplaceAllStringFunc(s, func(match string) string {
+			if !luhnValid(match) {
+				return match
+			}
+			return RedactedMask
+		})
+	})
+}
+
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		if number[i] < '0' || number[i] > '9' {
+			continue
+		}
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 { //nolint:gomnd
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// newKeysRedactor returns a redactor masking values of the keys
+// given in strings, such as `password=value`, `password: value`
+// or `"password":"value"`. Keys are matched case insensitively.
+// Quoted values are masked up to their closing quote, and unquoted
+// values are masked together with their authorization scheme such
+// as `Bearer token`.
+func newKeysRedactor(keys []string) Redactor {
+	quotedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		quotedKeys[i] = regexp.QuoteMeta(key)
+	}
+	regex := regexp.MustCompile(`(?i)\b((?:` + strings.Join(quotedKeys, "|") +
+		`)"?\s*[=:]\s*)(?:(")(?:[^"\\]|\\.)*"|(?:(?:bearer|basic)\s+)?[^\s",&;]+)`)
+	return RedactorFunc(func(s string) string {
+		return regex.ReplaceAllStringFunc(s, func(match string) string {
+			submatches := regex.FindStringSubmatch(match)
+			prefix, quote := submatches[1], submatches[2]
+			return prefix + quote + RedactedMask + quote
+		})
+	})
+}
+
+func redact(record Record, redactedKeys []string,
+	redactors []Redactor) (redacted Record) {
+	if len(redactedKeys) == 0 && len(redactors) == 0 {
+		return record
+	}
+
+	record.Message = applyRedactors(record.Message, redactors)
+
+	if len(record.Fields) == 0 {
+		return record
+	}
+
+	fields := make([]Field, len(record.Fields))
+	for i, field := range record.Fields {
+		fields[i] = field
+		if isRedactedKey(field.Key, redactedKeys) {
+			fields[i].Value = RedactedMask
+			continue
+		}
+
+		value := fmt.Sprint(field.Value)
+		redactedValue := applyRedactors(value, redactors)
+		if redactedValue != value {
+			fields[i].Value = redactedValue
+		}
+	}
+	record.Fields = fields
+
+	return record
+}
+
+func applyRedactors(s string, redactors []Redactor) string {
+	for _, redactor := range redactors {
+		s = redactor.Redact(s)
+	}
+	return s
+}
+
+func isRedactedKey(key string, redactedKeys []string) bool {
+	for _, redactedKey := range redactedKeys {
+		if strings.EqualFold(key, redactedKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package log
+
+// SampledOut returns the number of records dropped by
+// the sampling of the logger, configured with the
+// SetSampling option. Since the sampling state is shared
+// with child loggers, this includes records dropped by
+// child loggers sharing it.
+// It returns 0 if sampling is not enabled.
+func (l *Logger) SampledOut() (dropped uint64) {
+	if l.members != nil {
+		return l.membersSampledOut()
+	}
+
+	l.settingsMutex.RLock()
+	sampler := l.settings.sampler
+	l.settingsMutex.RUnlock()
+
+	if sampler == nil {
+		return 0
+	}
+	return sampler.Dropped()
+}
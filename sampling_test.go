@@ -0,0 +1,33 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Logger_SampledOut(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+	parent := New(SetWriters(buffer), SetSampling(2, 0, time.Hour))
+	child := parent.New(SetComponent("child"))
+
+	parent.Infof("message %d", 1)
+	child.Infof("message %d", 2)
+	parent.Infof("message %d", 3)
+	child.Infof("message %d", 4)
+	child.Info("other message")
+	child.Warnf("message %d", 5)
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, uint64(2), parent.SampledOut())
+	assert.Equal(t, uint64(2), child.SampledOut())
+
+	unsampled := New(SetWriters(buffer))
+	assert.Zero(t, unsampled.SampledOut())
+}
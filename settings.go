@@ -6,14 +6,57 @@ import (
 	"time"
 
 	"github.com/qdm12/log/internal/caller"
+	"github.com/qdm12/log/internal/dedup"
+	"github.com/qdm12/log/internal/sampling"
 )
 
 type settings struct {
-	writers    []io.Writer
-	level      *Level
-	timeFormat *string
-	component  string
-	caller     caller.Settings
+	writers []io.Writer
+	// removedWriters are writers to remove from the writers
+	// inherited from a parent logger, and is only used when
+	// overriding settings without writers set.
+	removedWriters []io.Writer
+	level          *Level
+	// levelSpec is shared between a logger and its children.
+	levelSpec     *LevelSpec
+	timeFormat    *string
+	timestampMode *TimestampMode
+	// timeZone is the location used for record times,
+	// and is left unset to use the clock time location.
+	timeZone *time.Location
+	// clock is the function returning the current time,
+	// and is left unset to use time.Now.
+	clock func() time.Time
+	// start is the reference time for the elapsed timestamp
+	// mode, taken from the clock when it is set, and is left
+	// unset to use the process start time.
+	start     time.Time
+	multiline *MultilineMode
+	// theme is never modified once set, and is shared
+	// between a logger and its children.
+	theme *Theme
+	// aligner is shared between a logger and its children.
+	aligner   *aligner
+	component string
+	// componentAppend is set when the component should be
+	// appended to the parent component instead of replacing it.
+	componentAppend    bool
+	componentSeparator *string
+	caller             caller.Settings
+	// sampler is shared between a logger and its children.
+	sampler *sampling.Sampler
+	// deduplicator is shared between a logger and its children.
+	deduplicator *dedup.Deduplicator
+	hooks        []Hook
+	redactedKeys []string
+	redactors    []Redactor
+	// contextExtractors are used for the context-aware log methods.
+	contextExtractors []ContextExtractor
+	// stats is shared between a logger and its children.
+	stats             *statsCollector
+	writeErrorHandler WriteErrorHandler
+	// writerBreaker is shared between a logger and its children.
+	writerBreaker *writerBreaker
 }
 
 // newSettings returns settings using the options given
@@ -40,7 +83,31 @@ func (s *settings) setDefaults() {
 		s.timeFormat = &value
 	}
 
+	if s.timestampMode == nil {
+		value := TimestampLayout
+		s.timestampMode = &value
+	}
+
+	if s.multiline == nil {
+		value := MultilineRaw
+		s.multiline = &value
+	}
+
+	if s.componentSeparator == nil {
+		value := defaultComponentSeparator
+		s.componentSeparator = &value
+	}
+
+	if s.theme == nil {
+		value := DefaultTheme()
+		s.theme = &value
+	}
+
 	s.caller.SetDefaults()
+
+	if s.stats == nil {
+		s.stats = newStatsCollector()
+	}
 }
 
 func (s *settings) copy() (settingsCopy settings) {
@@ -54,21 +121,82 @@ func (s *settings) copy() (settingsCopy settings) {
 		settingsCopy.level = &level
 	}
 
+	settingsCopy.levelSpec = s.levelSpec
+
 	if s.timeFormat != nil {
 		timeFormat := *s.timeFormat
 		settingsCopy.timeFormat = &timeFormat
 	}
 
+	if s.timestampMode != nil {
+		timestampMode := *s.timestampMode
+		settingsCopy.timestampMode = &timestampMode
+	}
+
+	settingsCopy.timeZone = s.timeZone
+
+	settingsCopy.clock = s.clock
+	settingsCopy.start = s.start
+
+	if s.multiline != nil {
+		multiline := *s.multiline
+		settingsCopy.multiline = &multiline
+	}
+
+	settingsCopy.theme = s.theme
+
+	settingsCopy.aligner = s.aligner
+
 	settingsCopy.component = s.component
 
+	if s.componentSeparator != nil {
+		separator := *s.componentSeparator
+		settingsCopy.componentSeparator = &separator
+	}
+
 	settingsCopy.caller = s.caller.Copy()
 
+	settingsCopy.sampler = s.sampler
+
+	settingsCopy.deduplicator = s.deduplicator
+
+	if s.hooks != nil {
+		settingsCopy.hooks = make([]Hook, len(s.hooks))
+		copy(settingsCopy.hooks, s.hooks)
+	}
+
+	if s.redactedKeys != nil {
+		settingsCopy.redactedKeys = make([]string, len(s.redactedKeys))
+		copy(settingsCopy.redactedKeys, s.redactedKeys)
+	}
+
+	if s.redactors != nil {
+		settingsCopy.redactors = make([]Redactor, len(s.redactors))
+		copy(settingsCopy.redactors, s.redactors)
+	}
+
+	if s.contextExtractors != nil {
+		settingsCopy.contextExtractors = make([]ContextExtractor, len(s.contextExtractors))
+		copy(settingsCopy.contextExtractors, s.contextExtractors)
+	}
+
+	settingsCopy.stats = s.stats
+
+	settingsCopy.writeErrorHandler = s.writeErrorHandler
+
+	settingsCopy.writerBreaker = s.writerBreaker
+
 	return settingsCopy
 }
 
 func (s *settings) overrideWith(other settings) {
 	if len(other.writers) > 0 {
+		// options setting or adding writers replace the inherited
+		// writers, and writers removed by options are already
+		// removed from them in the order the options were given.
 		s.writers = other.writers
+	} else if len(other.removedWriters) > 0 {
+		s.writers = removeWriters(s.writers, other.removedWriters)
 	}
 
 	if other.level != nil {
@@ -76,14 +204,125 @@ func (s *settings) overrideWith(other settings) {
 		s.level = &value
 	}
 
+	if other.levelSpec != nil {
+		s.levelSpec = other.levelSpec
+	}
+
 	if other.timeFormat != nil {
 		value := *other.timeFormat
 		s.timeFormat = &value
 	}
 
-	if other.component != "" {
+	if other.timestampMode != nil {
+		value := *other.timestampMode
+		s.timestampMode = &value
+	}
+
+	if other.timeZone != nil {
+		s.timeZone = other.timeZone
+	}
+
+	if other.clock != nil {
+		s.clock = other.clock
+		s.start = other.start
+	}
+
+	if other.multiline != nil {
+		value := *other.multiline
+		s.multiline = &value
+	}
+
+	if other.theme != nil {
+		s.theme = other.theme
+	}
+
+	if other.aligner != nil {
+		s.aligner = other.aligner
+	}
+
+	if other.componentSeparator != nil {
+		value := *other.componentSeparator
+		s.componentSeparator = &value
+	}
+
+	if other.componentAppend {
+		separator := defaultComponentSeparator
+		if s.componentSeparator != nil {
+			separator = *s.componentSeparator
+		}
+		s.component = joinComponents(s.component, other.component, separator)
+	} else if other.component != "" {
 		s.component = other.component
 	}
 
 	s.caller.OverrideWith(other.caller)
+
+	if other.sampler != nil {
+		s.sampler = other.sampler
+	}
+
+	if other.deduplicator != nil {
+		s.deduplicator = other.deduplicator
+	}
+
+	if len(other.hooks) > 0 {
+		hooks := make([]Hook, 0, len(s.hooks)+len(other.hooks))
+		hooks = append(hooks, s.hooks...)
+		s.hooks = append(hooks, other.hooks...)
+	}
+
+	if len(other.redactedKeys) > 0 {
+		keys := make([]string, 0, len(s.redactedKeys)+len(other.redactedKeys))
+		keys = append(keys, s.redactedKeys...)
+		s.redactedKeys = append(keys, other.redactedKeys...)
+	}
+
+	if len(other.redactors) > 0 {
+		redactors := make([]Redactor, 0, len(s.redactors)+len(other.redactors))
+		redactors = append(redactors, s.redactors...)
+		s.redactors = append(redactors, other.redactors...)
+	}
+
+	if len(other.contextExtractors) > 0 {
+		extractors := make([]ContextExtractor, 0,
+			len(s.contextExtractors)+len(other.contextExtractors))
+		extractors = append(extractors, s.contextExtractors...)
+		s.contextExtractors = append(extractors, other.contextExtractors...)
+	}
+
+	if other.writeErrorHandler != nil {
+		s.writeErrorHandler = other.writeErrorHandler
+	}
+
+	if other.writerBreaker != nil {
+		s.writerBreaker = other.writerBreaker
+	}
+}
+
+// effectiveLevel returns the level of the logger, resolved
+// from the level specification if it is set and matches
+// the logger component.
+func (s *settings) effectiveLevel() Level {
+	if s.levelSpec != nil {
+		level, ok := s.levelSpec.Level(s.component, *s.componentSeparator)
+		if ok {
+			return level
+		}
+	}
+	return *s.level
+}
+
+// now returns the current time from the clock,
+// in the time zone if it is set.
+func (s *settings) now() time.Time {
+	now := time.Now
+	if s.clock != nil {
+		now = s.clock
+	}
+
+	t := now()
+	if s.timeZone != nil {
+		t = t.In(s.timeZone)
+	}
+	return t
 }
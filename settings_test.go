@@ -51,21 +51,26 @@ func Test_settings_setDefaults(t *testing.T) {
 	}{
 		"empty settings": {
 			expectedSettings: settings{
-				writers:    []io.Writer{os.Stdout},
-				level:      levelPtr(LevelInfo),
-				timeFormat: stringPtr(time.RFC3339),
-				caller: caller.Settings{
-					File: boolPtr(false),
-					Line: boolPtr(false),
-					Func: boolPtr(false),
-				},
+				writers:            []io.Writer{os.Stdout},
+				level:              levelPtr(LevelInfo),
+				timeFormat:         stringPtr(time.RFC3339),
+				multiline:          multilinePtr(MultilineRaw),
+				timestampMode:      timestampModePtr(TimestampLayout),
+				theme:              themePtr(DefaultTheme()),
+				componentSeparator: stringPtr("/"),
+				caller:             newCallerSettings(false, false, false),
+				stats:              newStatsCollector(),
 			},
 		},
 		"filled settings": {
 			initialSettings: settings{
-				writers:    []io.Writer{io.Discard},
-				level:      levelPtr(LevelWarn),
-				timeFormat: stringPtr(time.RFC1123),
+				writers:            []io.Writer{io.Discard},
+				level:              levelPtr(LevelWarn),
+				timeFormat:         stringPtr(time.RFC1123),
+				multiline:          multilinePtr(MultilineIndent),
+				timestampMode:      timestampModePtr(TimestampUnix),
+				theme:              themePtr(DefaultTheme()),
+				componentSeparator: stringPtr("/"),
 				caller: caller.Settings{
 					File: boolPtr(true),
 					Line: boolPtr(true),
@@ -73,14 +78,15 @@ func Test_settings_setDefaults(t *testing.T) {
 				},
 			},
 			expectedSettings: settings{
-				writers:    []io.Writer{io.Discard},
-				level:      levelPtr(LevelWarn),
-				timeFormat: stringPtr(time.RFC1123),
-				caller: caller.Settings{
-					File: boolPtr(true),
-					Line: boolPtr(true),
-					Func: boolPtr(true),
-				},
+				writers:            []io.Writer{io.Discard},
+				level:              levelPtr(LevelWarn),
+				timeFormat:         stringPtr(time.RFC1123),
+				multiline:          multilinePtr(MultilineIndent),
+				timestampMode:      timestampModePtr(TimestampUnix),
+				theme:              themePtr(DefaultTheme()),
+				componentSeparator: stringPtr("/"),
+				caller:             newCallerSettings(true, true, true),
+				stats:              newStatsCollector(),
 			},
 		},
 	}
@@ -212,6 +218,28 @@ func Test_settings_overrideWith(t *testing.T) {
 				},
 			},
 		},
+		"time zone overridden": {
+			initialSettings: settings{
+				timeZone: time.Local,
+			},
+			overrideSettings: settings{
+				timeZone: time.UTC,
+			},
+			expectedSettings: settings{
+				timeZone: time.UTC,
+			},
+		},
+		"hooks appended": {
+			initialSettings: settings{
+				hooks: []Hook{testFieldHook{key: "a"}},
+			},
+			overrideSettings: settings{
+				hooks: []Hook{testFieldHook{key: "b"}},
+			},
+			expectedSettings: settings{
+				hooks: []Hook{testFieldHook{key: "a"}, testFieldHook{key: "b"}},
+			},
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -226,3 +254,34 @@ func Test_settings_overrideWith(t *testing.T) {
 		})
 	}
 }
+
+func Test_settings_now(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2022, time.March, 28, 10, 3, 29, 0, time.UTC)
+	clock := func() time.Time { return fixedTime }
+	zone := time.FixedZone("UTC+2", 2*60*60)
+
+	t.Run("default clock", func(t *testing.T) {
+		t.Parallel()
+		settings := settings{}
+		before := time.Now()
+		now := settings.now()
+		assert.False(t, now.Before(before))
+	})
+
+	t.Run("clock", func(t *testing.T) {
+		t.Parallel()
+		settings := settings{clock: clock}
+		now := settings.now()
+		assert.Equal(t, fixedTime, now)
+	})
+
+	t.Run("clock and time zone", func(t *testing.T) {
+		t.Parallel()
+		settings := settings{clock: clock, timeZone: zone}
+		now := settings.now()
+		assert.Equal(t, "2022-03-28T12:03:29+02:00", now.Format(time.RFC3339))
+		assert.True(t, fixedTime.Equal(now))
+	})
+}
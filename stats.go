@@ -0,0 +1,269 @@
+package log
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Stats is a snapshot of the counters of a logger, which are
+// shared with all the loggers created from the same root logger.
+type Stats struct {
+	// Emitted is the number of records written,
+	// per level and per component.
+	Emitted map[Level]map[string]uint64
+	// Filtered is the number of records dropped
+	// because of level filtering, per level.
+	Filtered map[Level]uint64
+	// WriteErrors is the number of errors writing
+	// records, per writer name. The writer name is
+	// the file name for files, and its type and
+	// address otherwise.
+	WriteErrors map[string]uint64
+}
+
+// statsCollector counts records without a global lock on the
+// logging path: filtered records are counted with per level
+// atomic counters, and emitted records with per level atomic
+// counters of each component, looked up under a read lock.
+type statsCollector struct {
+	// filtered is accessed atomically, and is the first field
+	// to be 64-bit aligned on 32-bit platforms.
+	filtered levelCounters
+
+	componentsMutex sync.RWMutex
+	// emitted maps components to their per level counters,
+	// which are accessed atomically.
+	emitted map[string]*levelCounters
+
+	writeErrorsMutex sync.Mutex
+	writeErrors      map[string]uint64
+}
+
+// levelCounters are counters indexed by level.
+type levelCounters [len(allLevels)]uint64
+
+func newStatsCollector() *statsCollector {
+	return &statsCollector{
+		emitted:     make(map[string]*levelCounters),
+		writeErrors: make(map[string]uint64),
+	}
+}
+
+func (c *statsCollector) addEmitted(level Level, component string) {
+	c.componentsMutex.RLock()
+	counters, ok := c.emitted[component]
+	c.componentsMutex.RUnlock()
+
+	if !ok {
+		c.componentsMutex.Lock()
+		counters, ok = c.emitted[component]
+		if !ok {
+			counters = new(levelCounters)
+			c.emitted[component] = counters
+		}
+		c.componentsMutex.Unlock()
+	}
+
+	atomic.AddUint64(&counters[level], 1)
+}
+
+func (c *statsCollector) addFiltered(level Level) {
+	atomic.AddUint64(&c.filtered[level], 1)
+}
+
+func (c *statsCollector) addWriteError(writer io.Writer) {
+	name := writerName(writer)
+	c.writeErrorsMutex.Lock()
+	c.writeErrors[name]++
+	c.writeErrorsMutex.Unlock()
+}
+
+func (c *statsCollector) snapshot() (stats Stats) {
+	stats = Stats{
+		Emitted:     make(map[Level]map[string]uint64),
+		Filtered:    make(map[Level]uint64),
+		WriteErrors: make(map[string]uint64),
+	}
+
+	c.componentsMutex.RLock()
+	for component, counters := range c.emitted {
+		for _, level := range allLevels {
+			count := atomic.LoadUint64(&counters[level])
+			if count == 0 {
+				continue
+			}
+			components, ok := stats.Emitted[level]
+			if !ok {
+				components = make(map[string]uint64)
+				stats.Emitted[level] = components
+			}
+			components[component] = count
+		}
+	}
+	c.componentsMutex.RUnlock()
+
+	for _, level := range allLevels {
+		count := atomic.LoadUint64(&c.filtered[level])
+		if count > 0 {
+			stats.Filtered[level] = count
+		}
+	}
+
+	c.writeErrorsMutex.Lock()
+	for name, count := range c.writeErrors {
+		stats.WriteErrors[name] = count
+	}
+	c.writeErrorsMutex.Unlock()
+
+	return stats
+}
+
+// merge adds the counters of the other stats given.
+func (s *Stats) merge(other Stats) {
+	for level, components := range other.Emitted {
+		merged, ok := s.Emitted[level]
+		if !ok {
+			merged = make(map[string]uint64, len(components))
+			s.Emitted[level] = merged
+		}
+		for component, count := range components {
+			merged[component] += count
+		}
+	}
+
+	for level, count := range other.Filtered {
+		s.Filtered[level] += count
+	}
+
+	for name, count := range other.WriteErrors {
+		s.WriteErrors[name] += count
+	}
+}
+
+func writerName(writer io.Writer) string {
+	file, ok := writer.(*os.File)
+	if ok {
+		return file.Name()
+	}
+	return fmt.Sprintf("%T(%p)", writer, writer)
+}
+
+// Stats returns a snapshot of the logger counters.
+// Counters are shared with the parent and child loggers,
+// such that the snapshot covers all loggers created from
+// the same root logger.
+func (l *Logger) Stats() (stats Stats) {
+	if l.members != nil {
+		return l.membersStats()
+	}
+
+	l.settingsMutex.RLock()
+	collector := l.settings.stats
+	l.settingsMutex.RUnlock()
+
+	if collector == nil {
+		return newStatsCollector().snapshot()
+	}
+	return collector.snapshot()
+}
+
+// PublishExpvar publishes the logger counters as an expvar
+// variable with the name given, which is then available in
+// JSON format, for example on the /debug/vars HTTP endpoint.
+// Like expvar.Publish, it panics if the name is already used.
+func (l *Logger) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return l.Stats().toExpvar()
+	}))
+}
+
+type expvarStats struct {
+	Emitted     map[string]map[string]uint64 `json:"emitted"`
+	Filtered    map[string]uint64            `json:"filtered"`
+	WriteErrors map[string]uint64            `json:"write_errors"`
+}
+
+func (s Stats) toExpvar() (stats expvarStats) {
+	stats = expvarStats{
+		Emitted:     make(map[string]map[string]uint64, len(s.Emitted)),
+		Filtered:    make(map[string]uint64, len(s.Filtered)),
+		WriteErrors: s.WriteErrors,
+	}
+
+	for level, components := range s.Emitted {
+		stats.Emitted[level.String()] = components
+	}
+
+	for level, count := range s.Filtered {
+		stats.Filtered[level.String()] = count
+	}
+
+	return stats
+}
+
+// StatsHandler returns an HTTP handler serving the logger counters
+// in the Prometheus text exposition format.
+func (l *Logger) StatsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = io.WriteString(w, l.Stats().prometheus())
+	})
+}
+
+// prometheus returns the stats in the Prometheus text exposition
+// format, with lines sorted for a deterministic output.
+func (s Stats) prometheus() string {
+	var lines []string
+
+	lines = append(lines,
+		"# HELP log_records_total Number of log records written.",
+		"# TYPE log_records_total counter")
+	var metricLines []string
+	for level, components := range s.Emitted {
+		for component, count := range components {
+			metricLines = append(metricLines, fmt.Sprintf(
+				`log_records_total{level="%s",component="%s"} %d`,
+				strings.ToLower(level.String()), escapeLabelValue(component), count))
+		}
+	}
+	sort.Strings(metricLines)
+	lines = append(lines, metricLines...)
+
+	lines = append(lines,
+		"# HELP log_records_filtered_total Number of log records dropped by level filtering.",
+		"# TYPE log_records_filtered_total counter")
+	metricLines = nil
+	for level, count := range s.Filtered {
+		metricLines = append(metricLines, fmt.Sprintf(
+			`log_records_filtered_total{level="%s"} %d`,
+			strings.ToLower(level.String()), count))
+	}
+	sort.Strings(metricLines)
+	lines = append(lines, metricLines...)
+
+	lines = append(lines,
+		"# HELP log_write_errors_total Number of errors writing log records.",
+		"# TYPE log_write_errors_total counter")
+	metricLines = nil
+	for name, count := range s.WriteErrors {
+		metricLines = append(metricLines, fmt.Sprintf(
+			`log_write_errors_total{writer="%s"} %d`, escapeLabelValue(name), count))
+	}
+	sort.Strings(metricLines)
+	lines = append(lines, metricLines...)
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func escapeLabelValue(s string) string {
+	quoted := strconv.Quote(s)
+	return quoted[1 : len(quoted)-1]
+}
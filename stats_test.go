@@ -0,0 +1,141 @@
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFailingWriter struct{}
+
+func (w *testFailingWriter) Write(p []byte) (n int, err error) {
+	return 0, errors.New("test error")
+}
+
+func Test_statsCollector_concurrent(t *testing.T) {
+	t.Parallel()
+
+	collector := newStatsCollector()
+
+	const goroutines, iterations = 8, 100
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			component := fmt.Sprint(i % 2)
+			for j := 0; j < iterations; j++ {
+				collector.addEmitted(LevelInfo, component)
+				collector.addFiltered(LevelDebug)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	expectedStats := Stats{
+		Emitted: map[Level]map[string]uint64{
+			LevelInfo: {"0": goroutines / 2 * iterations, "1": goroutines / 2 * iterations},
+		},
+		Filtered: map[Level]uint64{
+			LevelDebug: goroutines * iterations,
+		},
+		WriteErrors: map[string]uint64{},
+	}
+	assert.Equal(t, expectedStats, collector.snapshot())
+}
+
+func Test_Logger_Stats(t *testing.T) {
+	t.Parallel()
+
+	failingWriter := &testFailingWriter{}
+	parent := New(SetWriters(io.Discard, failingWriter))
+	child := parent.New(SetComponent("http"), SetLevel(LevelWarn))
+
+	parent.Info("info")
+	parent.Debug("debug")
+	child.Warn("warn")
+	child.Warn("warn")
+	child.Info("info")
+
+	failingWriterName := writerName(failingWriter)
+	expectedStats := Stats{
+		Emitted: map[Level]map[string]uint64{
+			LevelInfo: {"": 1},
+			LevelWarn: {"http": 2},
+		},
+		Filtered: map[Level]uint64{
+			LevelDebug: 1,
+			LevelInfo:  1,
+		},
+		WriteErrors: map[string]uint64{
+			failingWriterName: 3,
+		},
+	}
+
+	assert.Equal(t, expectedStats, parent.Stats())
+	assert.Equal(t, expectedStats, child.Stats())
+
+	t.Run("prometheus handler", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(parent.StatsHandler())
+		defer server.Close()
+
+		response, err := http.Get(server.URL) //nolint:noctx
+		require.NoError(t, err)
+		defer response.Body.Close()
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+
+		expected := `# HELP log_records_total Number of log records written.
+# TYPE log_records_total counter
+log_records_total{level="info",component=""} 1
+log_records_total{level="warn",component="http"} 2
+# HELP log_records_filtered_total Number of log records dropped by level filtering.
+# TYPE log_records_filtered_total counter
+log_records_filtered_total{level="debug"} 1
+log_records_filtered_total{level="info"} 1
+# HELP log_write_errors_total Number of errors writing log records.
+# TYPE log_write_errors_total counter
+log_write_errors_total{writer="` + failingWriterName + `"} 3
+`
+		assert.Equal(t, expected, string(body))
+	})
+
+	t.Run("expvar", func(t *testing.T) {
+		t.Parallel()
+
+		parent.PublishExpvar("Test_Logger_Stats")
+
+		variable := expvar.Get("Test_Logger_Stats")
+		require.NotNil(t, variable)
+
+		var stats expvarStats
+		err := json.Unmarshal([]byte(variable.String()), &stats)
+		require.NoError(t, err)
+
+		expected := expvarStats{
+			Emitted: map[string]map[string]uint64{
+				"INFO": {"": 1},
+				"WARN": {"http": 2},
+			},
+			Filtered: map[string]uint64{
+				"DEBUG": 1,
+				"INFO":  1,
+			},
+			WriteErrors: map[string]uint64{
+				failingWriterName: 3,
+			},
+		}
+		assert.Equal(t, expected, stats)
+	})
+}
@@ -0,0 +1,15 @@
+//go:build !plan9
+// +build !plan9
+
+package log
+
+import (
+	"errors"
+	"syscall"
+)
+
+func isSyncNotSupported(err error) bool {
+	return errors.Is(err, syscall.EINVAL) ||
+		errors.Is(err, syscall.ENOTSUP) ||
+		errors.Is(err, syscall.ENOTTY)
+}
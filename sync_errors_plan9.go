@@ -0,0 +1,10 @@
+package log
+
+import (
+	"errors"
+	"syscall"
+)
+
+func isSyncNotSupported(err error) bool {
+	return errors.Is(err, syscall.EINVAL)
+}
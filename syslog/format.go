@@ -0,0 +1,171 @@
+package syslog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qdm12/log"
+)
+
+// Format is the syslog message format.
+type Format uint8
+
+const (
+	// RFC5424 is the syslog format defined in RFC 5424.
+	RFC5424 Format = iota
+	// RFC3164 is the legacy BSD syslog format defined in RFC 3164.
+	RFC3164
+)
+
+// LevelToSeverity returns the syslog severity corresponding
+// to the log level given.
+func LevelToSeverity(level log.Level) Severity {
+	switch level {
+	case log.LevelError:
+		return SeverityError
+	case log.LevelWarn:
+		return SeverityWarning
+	case log.LevelInfo:
+		return SeverityInformational
+	case log.LevelDebug:
+		return SeverityDebug
+	default:
+		return SeverityNotice
+	}
+}
+
+type header struct {
+	facility Facility
+	hostname string
+	appName  string
+	pid      int
+	sdID     string
+}
+
+func priority(facility Facility, level log.Level) string {
+	const facilityMultiplier = 8
+	value := int(facility)*facilityMultiplier + int(LevelToSeverity(level))
+	return "<" + strconv.Itoa(value) + ">"
+}
+
+// formatRFC5424 formats the record as
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD] MSG.
+func formatRFC5424(h header, record log.Record) string {
+	const timestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+	const maxHostnameLength, maxAppNameLength = 255, 48
+
+	fields := []string{
+		priority(h.facility, record.Level) + "1",
+		record.Time.Format(timestampFormat),
+		nilValue(truncate(printableASCII(h.hostname), maxHostnameLength)),
+		nilValue(truncate(printableASCII(h.appName), maxAppNameLength)),
+		strconv.Itoa(h.pid),
+		"-",
+		structuredData(h.sdID, record),
+	}
+
+	s := strings.Join(fields, " ")
+	if record.Message != "" {
+		s += " " + record.Message
+	}
+	return s
+}
+
+func structuredData(sdID string, record log.Record) string {
+	var params []string
+	if record.Component != "" {
+		params = append(params, sdParam("component", record.Component))
+	}
+
+	if record.Caller.File != "" {
+		params = append(params,
+			sdParam("file", record.Caller.File),
+			sdParam("line", strconv.Itoa(record.Caller.Line)),
+		)
+		if record.Caller.Function != "" {
+			params = append(params, sdParam("func", record.Caller.Function))
+		}
+	}
+
+	for _, field := range record.Fields {
+		params = append(params, sdParam(field.Key, fmt.Sprint(field.Value)))
+	}
+
+	if len(params) == 0 {
+		return "-"
+	}
+
+	return "[" + sdID + " " + strings.Join(params, " ") + "]"
+}
+
+func sdParam(name, value string) string {
+	const maxNameLength = 32
+	name = truncate(sdName(name), maxNameLength)
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+	return name + `="` + replacer.Replace(value) + `"`
+}
+
+// sdName returns the name with characters not allowed
+// in a structured data name replaced by underscores.
+func sdName(name string) string {
+	if name == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// formatRFC3164 formats the record as
+// <PRI>Mmm dd hh:mm:ss HOSTNAME TAG[PID]: MSG.
+func formatRFC3164(h header, record log.Record) string {
+	const maxTagLength = 32
+	tag := truncate(strings.ReplaceAll(printableASCII(h.appName), "-", "_"), maxTagLength)
+
+	s := priority(h.facility, record.Level) +
+		record.Time.Format(time.Stamp) + " " +
+		nilValue(printableASCII(h.hostname)) + " " +
+		tag + "[" + strconv.Itoa(h.pid) + "]: "
+
+	if record.Component != "" {
+		s += "[" + record.Component + "] "
+	}
+
+	s += record.Message
+
+	for _, field := range record.Fields {
+		s += " " + field.String()
+	}
+
+	return s
+}
+
+// printableASCII removes characters not allowed in
+// header fields such as the hostname and app name.
+func printableASCII(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func nilValue(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func truncate(s string, maxLength int) string {
+	if len(s) > maxLength {
+		return s[:maxLength]
+	}
+	return s
+}
@@ -0,0 +1,97 @@
+package syslog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qdm12/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LevelToSeverity(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, SeverityError, LevelToSeverity(log.LevelError))
+	assert.Equal(t, SeverityWarning, LevelToSeverity(log.LevelWarn))
+	assert.Equal(t, SeverityInformational, LevelToSeverity(log.LevelInfo))
+	assert.Equal(t, SeverityDebug, LevelToSeverity(log.LevelDebug))
+}
+
+func Test_formatRFC5424(t *testing.T) {
+	t.Parallel()
+
+	h := header{
+		facility: FacilityLocal0,
+		hostname: "host name",
+		appName:  "app",
+		pid:      123,
+		sdID:     "log@32473",
+	}
+
+	testCases := map[string]struct {
+		record  log.Record
+		message string
+	}{
+		"minimal record": {
+			record: log.Record{
+				Level: log.LevelInfo,
+				Time:  time.Date(2022, 3, 4, 5, 6, 7, 8000, time.UTC),
+			},
+			message: "<134>1 2022-03-04T05:06:07.000008Z hostname app 123 - -",
+		},
+		"full record": {
+			record: log.Record{
+				Level:     log.LevelError,
+				Time:      time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC),
+				Component: "http",
+				Message:   "some message",
+				Caller: log.Caller{
+					File:     "/a/main.go",
+					Line:     12,
+					Function: "main.main",
+				},
+				Fields: []log.Field{
+					{Key: "user id", Value: `a"b]`},
+					{Key: "count", Value: 2},
+				},
+			},
+			message: `<131>1 2022-03-04T05:06:07.000000Z hostname app 123 - ` +
+				`[log@32473 component="http" file="/a/main.go" line="12" func="main.main" ` +
+				`user_id="a\"b\]" count="2"] some message`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			message := formatRFC5424(h, testCase.record)
+
+			assert.Equal(t, testCase.message, message)
+		})
+	}
+}
+
+func Test_formatRFC3164(t *testing.T) {
+	t.Parallel()
+
+	h := header{
+		facility: FacilityDaemon,
+		hostname: "host",
+		appName:  "my-app",
+		pid:      123,
+	}
+
+	record := log.Record{
+		Level:     log.LevelWarn,
+		Time:      time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC),
+		Component: "db",
+		Message:   "slow query",
+		Fields:    []log.Field{{Key: "duration", Value: time.Second}},
+	}
+
+	message := formatRFC3164(h, record)
+
+	assert.Equal(t, "<28>Mar  4 05:06:07 host my_app[123]: [db] slow query duration=1s", message)
+}
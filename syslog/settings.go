@@ -0,0 +1,94 @@
+package syslog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Settings contains settings for the syslog writer.
+type Settings struct {
+	// Network is the network to use to reach the syslog
+	// server, and can be "udp", "tcp", "unix" or "unixgram".
+	// It defaults to "udp".
+	Network string
+	// Address is the address of the syslog server, for example
+	// "127.0.0.1:514" or "/dev/log" for unix sockets.
+	// It cannot be empty.
+	Address string
+	// Facility is the syslog facility to use.
+	// It defaults to FacilityUser.
+	Facility *Facility
+	// AppName is the application name to use.
+	// It defaults to the base name of the program.
+	AppName string
+	// Hostname is the hostname to use.
+	// It defaults to the hostname reported by the kernel.
+	Hostname string
+	// Format is the syslog message format to use.
+	// It defaults to RFC5424.
+	Format *Format
+	// StructuredDataID is the structured data identifier
+	// used in RFC 5424 messages for the record component,
+	// caller and fields.
+	// It defaults to "log@32473".
+	StructuredDataID string
+}
+
+func (s *Settings) setDefaults() {
+	if s.Network == "" {
+		s.Network = "udp"
+	}
+
+	if s.Facility == nil {
+		facility := FacilityUser
+		s.Facility = &facility
+	}
+
+	if s.AppName == "" {
+		s.AppName = filepath.Base(os.Args[0])
+	}
+
+	if s.Hostname == "" {
+		s.Hostname, _ = os.Hostname()
+	}
+
+	if s.Format == nil {
+		format := RFC5424
+		s.Format = &format
+	}
+
+	if s.StructuredDataID == "" {
+		s.StructuredDataID = "log@32473"
+	}
+}
+
+var (
+	ErrNetworkNotSupported = errors.New("network is not supported")
+	ErrAddressNotSet       = errors.New("address is not set")
+	ErrFacilityNotValid    = errors.New("facility is not valid")
+	ErrFormatNotValid      = errors.New("format is not valid")
+)
+
+func (s Settings) validate() (err error) {
+	switch s.Network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "unix", "unixgram":
+	default:
+		return fmt.Errorf("%w: %s", ErrNetworkNotSupported, s.Network)
+	}
+
+	if s.Address == "" {
+		return fmt.Errorf("%w", ErrAddressNotSet)
+	}
+
+	if *s.Facility > FacilityLocal7 {
+		return fmt.Errorf("%w: %d", ErrFacilityNotValid, *s.Facility)
+	}
+
+	if *s.Format != RFC5424 && *s.Format != RFC3164 {
+		return fmt.Errorf("%w: %d", ErrFormatNotValid, *s.Format)
+	}
+
+	return nil
+}
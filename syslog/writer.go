@@ -0,0 +1,146 @@
+package syslog
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qdm12/log"
+)
+
+var _ log.RecordWriter = (*Writer)(nil)
+
+// Writer is a syslog writer, which can be given to a logger
+// with the log.SetWriters or log.AddWriters options.
+// It is thread safe to use.
+type Writer struct {
+	settings Settings
+	header   header
+	framed   bool
+
+	mutex  sync.Mutex
+	conn   net.Conn
+	closed bool
+}
+
+// ErrWriterClosed is returned when writing to a closed writer.
+var ErrWriterClosed = errors.New("syslog writer is closed")
+
+// New creates a new syslog writer and connects it to the
+// syslog server.
+func New(settings Settings) (writer *Writer, err error) {
+	settings.setDefaults()
+	err = settings.validate()
+	if err != nil {
+		return nil, fmt.Errorf("validating settings: %w", err)
+	}
+
+	writer = &Writer{
+		settings: settings,
+		header: header{
+			facility: *settings.Facility,
+			hostname: settings.Hostname,
+			appName:  settings.AppName,
+			pid:      os.Getpid(),
+			sdID:     settings.StructuredDataID,
+		},
+		// stream transports use octet counting framing,
+		// see RFC 6587 section 3.4.1.
+		framed: !strings.HasPrefix(settings.Network, "udp") &&
+			settings.Network != "unixgram",
+	}
+
+	writer.conn, err = net.Dial(settings.Network, settings.Address)
+	if err != nil {
+		return nil, fmt.Errorf("dialing syslog server: %w", err)
+	}
+
+	return writer, nil
+}
+
+// WriteRecord formats and sends the record to the syslog server.
+func (w *Writer) WriteRecord(record log.Record) (err error) {
+	var message string
+	switch *w.settings.Format {
+	case RFC3164:
+		message = formatRFC3164(w.header, record)
+	default:
+		message = formatRFC5424(w.header, record)
+	}
+
+	if w.framed {
+		message = strconv.Itoa(len(message)) + " " + message
+	}
+
+	return w.send(message)
+}
+
+// Write sends the data given as a message at the
+// informational severity to the syslog server, with
+// any trailing new line removed.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	record := log.Record{
+		Level:   log.LevelInfo,
+		Time:    time.Now(),
+		Message: strings.TrimSuffix(string(p), "\n"),
+	}
+
+	err = w.WriteRecord(record)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *Writer) send(message string) (err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return fmt.Errorf("%w", ErrWriterClosed)
+	}
+
+	if w.conn != nil {
+		_, err = w.conn.Write([]byte(message))
+		if err == nil {
+			return nil
+		}
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+
+	// try to reconnect once
+	w.conn, err = net.Dial(w.settings.Network, w.settings.Address)
+	if err != nil {
+		return fmt.Errorf("dialing syslog server: %w", err)
+	}
+
+	_, err = w.conn.Write([]byte(message))
+	if err != nil {
+		return fmt.Errorf("writing to syslog server: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection to the syslog server.
+func (w *Writer) Close() (err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.closed = true
+	if w.conn == nil {
+		return nil
+	}
+
+	err = w.conn.Close()
+	w.conn = nil
+	if err != nil {
+		return fmt.Errorf("closing connection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package syslog
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qdm12/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Writer_UDP(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	writer, err := New(Settings{
+		Network:  "udp",
+		Address:  listener.LocalAddr().String(),
+		AppName:  "app",
+		Hostname: "host",
+	})
+	require.NoError(t, err)
+	defer writer.Close()
+
+	logger := log.New(log.SetWriters(writer), log.SetComponent("component"))
+	logger.Warn("some message")
+
+	buffer := make([]byte, 1024)
+	_ = listener.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := listener.ReadFrom(buffer)
+	require.NoError(t, err)
+
+	regex := regexp.MustCompile(`^<12>1 \S+ host app [0-9]+ - ` +
+		`\[log@32473 component="component"\] some message$`)
+	message := string(buffer[:n])
+	assert.Truef(t, regex.MatchString(message), "message %q does not match", message)
+}
+
+func Test_Writer_TCP(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	format := RFC3164
+	writer, err := New(Settings{
+		Network:  "tcp",
+		Address:  listener.Addr().String(),
+		AppName:  "app",
+		Hostname: "host",
+		Format:   &format,
+	})
+	require.NoError(t, err)
+	defer writer.Close()
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = writer.Write([]byte("first\n"))
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("second\n"))
+	require.NoError(t, err)
+
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	reader := bufio.NewReader(conn)
+	for _, expected := range []string{"first", "second"} {
+		lengthString, err := reader.ReadString(' ')
+		require.NoError(t, err)
+		length, err := strconv.Atoi(strings.TrimSuffix(lengthString, " "))
+		require.NoError(t, err)
+
+		message := make([]byte, length)
+		_, err = io.ReadFull(reader, message)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(string(message), "<14>"))
+		assert.True(t, strings.HasSuffix(string(message), " host app["+
+			strconv.Itoa(writer.header.pid)+"]: "+expected))
+	}
+}
+
+func Test_Writer_Unixgram(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "syslog.sock")
+	listener, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	writer, err := New(Settings{
+		Network: "unixgram",
+		Address: path,
+	})
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("message"))
+	require.NoError(t, err)
+
+	buffer := make([]byte, 1024)
+	_ = listener.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := listener.ReadFrom(buffer)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(buffer[:n]), " - - message"))
+
+	err = writer.Close()
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("message"))
+	assert.ErrorIs(t, err, ErrWriterClosed)
+}
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Settings{Network: "ip"})
+	assert.ErrorIs(t, err, ErrNetworkNotSupported)
+
+	_, err = New(Settings{})
+	assert.ErrorIs(t, err, ErrAddressNotSet)
+
+	facility := Facility(24)
+	_, err = New(Settings{Address: "127.0.0.1:514", Facility: &facility})
+	assert.ErrorIs(t, err, ErrFacilityNotValid)
+}
@@ -0,0 +1,166 @@
+package log
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// Theme defines the labels and colors used to render log lines.
+// A nil color leaves the corresponding text uncolored.
+// Colors are automatically disabled if the output is not a
+// terminal, unless forced with color.Color.EnableColor.
+type Theme struct {
+	// Levels maps each level to its label and color.
+	// A level missing from the map is rendered with
+	// its upper case name and without color.
+	Levels map[Level]LevelStyle
+	// PadLevels pads level labels with spaces up to the
+	// width of the longest level label, such that messages
+	// are aligned.
+	PadLevels bool
+	// Timestamp is the color for the timestamp.
+	Timestamp *color.Color
+	// Component is the color for the component tag.
+	Component *color.Color
+	// ComponentPalette, if not empty, is the palette of colors
+	// to color the component tag with, instead of Component.
+	// The color is chosen from a hash of the component, so a
+	// component always has the same color across runs.
+	ComponentPalette []*color.Color
+	// Caller is the color for the caller information.
+	Caller *color.Color
+	// FieldKey is the color for the record field keys.
+	FieldKey *color.Color
+}
+
+// LevelStyle defines how a level is rendered.
+type LevelStyle struct {
+	// Label is the text written for the level, and
+	// defaults to the upper case level name if empty.
+	Label string
+	// Color is the color of the level label.
+	Color *color.Color
+	// ColorLine colors the whole line with the level
+	// color instead of only the level label.
+	ColorLine bool
+}
+
+// DefaultTheme returns the default theme of the logger.
+// The theme returned can be modified safely.
+func DefaultTheme() Theme {
+	levels := make(map[Level]LevelStyle, len(allLevels))
+	for _, level := range allLevels {
+		levels[level] = LevelStyle{
+			Color: color.New(level.colorAttribute()),
+		}
+	}
+
+	return Theme{
+		Levels: levels,
+		Caller: color.New(color.FgHiWhite),
+	}
+}
+
+// WithShortLabels returns a copy of the theme with its level
+// labels set to the short forms DBG, INF, WRN and ERR.
+func (t Theme) WithShortLabels() Theme {
+	shortLabels := map[Level]string{
+		LevelDebug: "DBG",
+		LevelInfo:  "INF",
+		LevelWarn:  "WRN",
+		LevelError: "ERR",
+	}
+
+	t = t.copy()
+	if t.Levels == nil {
+		t.Levels = make(map[Level]LevelStyle, len(shortLabels))
+	}
+	for level, label := range shortLabels {
+		style := t.Levels[level]
+		style.Label = label
+		t.Levels[level] = style
+	}
+	return t
+}
+
+// DefaultComponentPalette returns a palette of colors readable
+// on both dark and light backgrounds, to be used for the theme
+// ComponentPalette field.
+func DefaultComponentPalette() []*color.Color {
+	attributes := []color.Attribute{
+		color.FgGreen, color.FgMagenta, color.FgBlue, color.FgCyan,
+		color.FgYellow, color.FgRed, color.FgHiGreen, color.FgHiMagenta,
+		color.FgHiBlue, color.FgHiCyan,
+	}
+	palette := make([]*color.Color, len(attributes))
+	for i, attribute := range attributes {
+		palette[i] = color.New(attribute)
+	}
+	return palette
+}
+
+func (t Theme) copy() Theme {
+	if t.Levels != nil {
+		levels := make(map[Level]LevelStyle, len(t.Levels))
+		for level, style := range t.Levels {
+			levels[level] = style
+		}
+		t.Levels = levels
+	}
+
+	if t.ComponentPalette != nil {
+		palette := make([]*color.Color, len(t.ComponentPalette))
+		copy(palette, t.ComponentPalette)
+		t.ComponentPalette = palette
+	}
+
+	return t
+}
+
+// componentColor returns the color for the component given.
+func (t *Theme) componentColor(component string) *color.Color {
+	if len(t.ComponentPalette) == 0 {
+		return t.Component
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(component))
+	index := hash.Sum32() % uint32(len(t.ComponentPalette))
+	return t.ComponentPalette[index]
+}
+
+func (t *Theme) levelStyle(level Level) (style LevelStyle) {
+	style = t.Levels[level]
+	if style.Label == "" {
+		style.Label = level.String()
+	}
+	return style
+}
+
+// levelLabel returns the label for the level, padded
+// with spaces if the theme pads level labels.
+func (t *Theme) levelLabel(level Level) (label string) {
+	label = t.levelStyle(level).Label
+	if !t.PadLevels {
+		return label
+	}
+
+	maxWidth := 0
+	for _, otherLevel := range allLevels {
+		width := utf8.RuneCountInString(t.levelStyle(otherLevel).Label)
+		if width > maxWidth {
+			maxWidth = width
+		}
+	}
+	return label + strings.Repeat(" ", maxWidth-utf8.RuneCountInString(label))
+}
+
+func paint(c *color.Color, s string) string {
+	if c == nil {
+		return s
+	}
+	return c.Sprint(s)
+}
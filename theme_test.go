@@ -0,0 +1,183 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Theme_WithShortLabels(t *testing.T) {
+	t.Parallel()
+
+	theme := DefaultTheme()
+	shortTheme := theme.WithShortLabels()
+
+	assert.Equal(t, "INFO", theme.levelLabel(LevelInfo))
+	assert.Equal(t, "INF", shortTheme.levelLabel(LevelInfo))
+	assert.Equal(t, "ERR", shortTheme.levelLabel(LevelError))
+	assert.Equal(t, theme.Levels[LevelWarn].Color, shortTheme.Levels[LevelWarn].Color)
+}
+
+func Test_Theme_levelLabel(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		theme Theme
+		level Level
+		label string
+	}{
+		"empty theme": {
+			level: LevelWarn,
+			label: "WARN",
+		},
+		"padded": {
+			theme: Theme{PadLevels: true},
+			level: LevelInfo,
+			label: "INFO ",
+		},
+		"padded longest": {
+			theme: Theme{PadLevels: true},
+			level: LevelDebug,
+			label: "DEBUG",
+		},
+		"custom padded label": {
+			theme: Theme{
+				Levels: map[Level]LevelStyle{
+					LevelError: {Label: "FAILURE"},
+				},
+				PadLevels: true,
+			},
+			level: LevelInfo,
+			label: "INFO   ",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			label := testCase.theme.levelLabel(testCase.level)
+
+			assert.Equal(t, testCase.label, label)
+		})
+	}
+}
+
+func Test_formatRecord_theme(t *testing.T) {
+	t.Parallel()
+
+	// Colors are forced since tests do not run in a terminal.
+	red := color.New(color.FgRed)
+	red.EnableColor()
+	green := color.New(color.FgGreen)
+	green.EnableColor()
+	blue := color.New(color.FgBlue)
+	blue.EnableColor()
+
+	record := Record{
+		Level:     LevelWarn,
+		Time:      time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC),
+		Component: "db",
+		Message:   "message",
+		Fields:    []Field{{Key: "rows", Value: 2}},
+		Caller:    Caller{File: "/a/main.go", Line: 10, Function: "main.main"},
+	}
+
+	testCases := map[string]struct {
+		theme Theme
+		line  string
+	}{
+		"no color short labels padded": {
+			theme: Theme{PadLevels: true}.WithShortLabels(),
+			line:  "2022-03-04T05:06:07Z WRN [db] message rows=2\tmain.go\n",
+		},
+		"parts colors": {
+			theme: Theme{
+				Levels: map[Level]LevelStyle{
+					LevelWarn: {Label: "W", Color: red},
+				},
+				Timestamp: blue,
+				Component: green,
+				Caller:    blue,
+				FieldKey:  green,
+			},
+			line: blue.Sprint("2022-03-04T05:06:07Z") + " " + red.Sprint("W") + " " +
+				green.Sprint("[db]") + " message " + green.Sprint("rows") + "=2\t" +
+				blue.Sprint("main.go") + "\n",
+		},
+		"line color": {
+			theme: Theme{
+				Levels: map[Level]LevelStyle{
+					LevelWarn: {Color: red, ColorLine: true},
+				},
+				Component: green,
+			},
+			line: red.Sprint("2022-03-04T05:06:07Z WARN [db] message rows=2\tmain.go") + "\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			settings := settings{
+				timeFormat:    stringPtr(time.RFC3339),
+				timestampMode: timestampModePtr(TimestampLayout),
+				multiline:     multilinePtr(MultilineRaw),
+				theme:         &testCase.theme,
+				caller:        newCallerSettings(true, false, false),
+			}
+
+			line := formatRecord(settings, record).String()
+
+			assert.Equal(t, testCase.line, line)
+		})
+	}
+}
+
+func Test_Theme_componentColor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without palette", func(t *testing.T) {
+		t.Parallel()
+		green := color.New(color.FgGreen)
+		theme := Theme{Component: green}
+		assert.Same(t, green, theme.componentColor("db"))
+	})
+
+	t.Run("with palette", func(t *testing.T) {
+		t.Parallel()
+		palette := DefaultComponentPalette()
+		theme := Theme{
+			Component:        color.New(color.FgGreen),
+			ComponentPalette: palette,
+		}
+
+		// FNV-1a 32 bits hash of "db" is 0x571cc6c3 which gives
+		// index 3 and "api" is 0x386aaa87 which gives index 7.
+		assert.Same(t, palette[3], theme.componentColor("db"))
+		assert.Same(t, palette[7], theme.componentColor("api"))
+		assert.Same(t, theme.componentColor("db"), theme.componentColor("db"))
+	})
+}
+
+func Test_Logger_componentPalette(t *testing.T) {
+	t.Parallel()
+
+	red := color.New(color.FgRed)
+	red.EnableColor()
+	buffer := bytes.NewBuffer(nil)
+
+	theme := DefaultTheme()
+	theme.ComponentPalette = []*color.Color{red}
+	parent := New(SetWriters(buffer), SetTimeFormat(""), SetTheme(theme))
+	child := parent.New(SetComponent("db"))
+	child.Info("message")
+
+	assert.Equal(t, "INFO "+red.Sprint("[db]")+" message\n", buffer.String())
+}
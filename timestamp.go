@@ -0,0 +1,122 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimestampMode is the mode to render the time of records.
+type TimestampMode uint8
+
+const (
+	// TimestampLayout formats the time using the time format
+	// layout set with SetTimeFormat.
+	TimestampLayout TimestampMode = iota
+	// TimestampUnix formats the time as the number of seconds
+	// elapsed since the Unix epoch.
+	TimestampUnix
+	// TimestampUnixMilli formats the time as the number of
+	// milliseconds elapsed since the Unix epoch.
+	TimestampUnixMilli
+	// TimestampUnixMicro formats the time as the number of
+	// microseconds elapsed since the Unix epoch.
+	TimestampUnixMicro
+	// TimestampUnixNano formats the time as the number of
+	// nanoseconds elapsed since the Unix epoch.
+	TimestampUnixNano
+	// TimestampElapsed formats the time as the duration in
+	// seconds elapsed since the process start, such as 12.345678s.
+	// If a clock is set with SetClock, the duration is elapsed
+	// since the clock time when the option was applied.
+	TimestampElapsed
+)
+
+func (m TimestampMode) String() string {
+	switch m {
+	case TimestampLayout:
+		return "layout"
+	case TimestampUnix:
+		return "unix"
+	case TimestampUnixMilli:
+		return "unixms"
+	case TimestampUnixMicro:
+		return "unixus"
+	case TimestampUnixNano:
+		return "unixns"
+	case TimestampElapsed:
+		return "elapsed"
+	default:
+		panic(fmt.Sprintf("timestamp mode %d is unknown", m))
+	}
+}
+
+var ErrTimestampModeNotRecognized = errors.New("timestamp mode is not recognized")
+
+// ParseTimestampMode parses a string into a timestamp mode,
+// and returns an error if it fails.
+func ParseTimestampMode(s string) (mode TimestampMode, err error) {
+	for _, mode := range []TimestampMode{TimestampLayout, TimestampUnix,
+		TimestampUnixMilli, TimestampUnixMicro, TimestampUnixNano,
+		TimestampElapsed} {
+		if strings.EqualFold(s, mode.String()) {
+			return mode, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrTimestampModeNotRecognized, s)
+}
+
+var ErrTimestampPresetNotRecognized = errors.New("timestamp preset is not recognized")
+
+// ParseTimestampPreset parses a named timestamp preset, typically
+// coming from a configuration file, and returns an option setting
+// the timestamp mode and time format accordingly. Presets are:
+// rfc3339, rfc3339nano, unix, unixms, unixus, unixns and elapsed.
+// Parsing is case insensitive.
+func ParseTimestampPreset(s string) (option Option, err error) {
+	switch strings.ToLower(s) {
+	case "rfc3339":
+		return setTimestamp(TimestampLayout, time.RFC3339), nil
+	case "rfc3339nano":
+		return setTimestamp(TimestampLayout, time.RFC3339Nano), nil
+	}
+
+	mode, err := ParseTimestampMode(s)
+	if err != nil || mode == TimestampLayout {
+		return nil, fmt.Errorf("%w: %s", ErrTimestampPresetNotRecognized, s)
+	}
+	return SetTimestampMode(mode), nil
+}
+
+func setTimestamp(mode TimestampMode, layout string) Option {
+	return func(s *settings) {
+		s.timestampMode = &mode
+		s.timeFormat = &layout
+	}
+}
+
+// processStart is the reference time for the elapsed
+// timestamp mode.
+var processStart = time.Now() //nolint:gochecknoglobals
+
+// formatTimestamp returns the time formatted according to the
+// mode and layout given. It returns the empty string if the
+// mode is TimestampLayout and the layout is empty.
+func formatTimestamp(mode TimestampMode, layout string, t, start time.Time) string {
+	switch mode {
+	case TimestampUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case TimestampUnixMilli:
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	case TimestampUnixMicro:
+		return strconv.FormatInt(t.UnixMicro(), 10)
+	case TimestampUnixNano:
+		return strconv.FormatInt(t.UnixNano(), 10)
+	case TimestampElapsed:
+		return fmt.Sprintf("%.6fs", t.Sub(start).Seconds())
+	default:
+		return t.Format(layout)
+	}
+}
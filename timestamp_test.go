@@ -0,0 +1,209 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseTimestampMode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		s    string
+		mode TimestampMode
+		err  error
+	}{
+		"layout": {
+			s:    "layout",
+			mode: TimestampLayout,
+		},
+		"unix milliseconds upper case": {
+			s:    "UNIXMS",
+			mode: TimestampUnixMilli,
+		},
+		"elapsed": {
+			s:    "elapsed",
+			mode: TimestampElapsed,
+		},
+		"invalid": {
+			s:   "invalid",
+			err: ErrTimestampModeNotRecognized,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mode, err := ParseTimestampMode(testCase.s)
+
+			assert.ErrorIs(t, err, testCase.err)
+			assert.Equal(t, testCase.mode, mode)
+		})
+	}
+}
+
+func Test_ParseTimestampPreset(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		s        string
+		settings settings
+		err      error
+	}{
+		"rfc3339": {
+			s: "rfc3339",
+			settings: settings{
+				timeFormat:    stringPtr(time.RFC3339),
+				timestampMode: timestampModePtr(TimestampLayout),
+			},
+		},
+		"rfc3339nano": {
+			s: "RFC3339Nano",
+			settings: settings{
+				timeFormat:    stringPtr(time.RFC3339Nano),
+				timestampMode: timestampModePtr(TimestampLayout),
+			},
+		},
+		"unixms": {
+			s: "unixms",
+			settings: settings{
+				timestampMode: timestampModePtr(TimestampUnixMilli),
+			},
+		},
+		"elapsed": {
+			s: "elapsed",
+			settings: settings{
+				timestampMode: timestampModePtr(TimestampElapsed),
+			},
+		},
+		"layout": {
+			s:   "layout",
+			err: ErrTimestampPresetNotRecognized,
+		},
+		"invalid": {
+			s:   "invalid",
+			err: ErrTimestampPresetNotRecognized,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			option, err := ParseTimestampPreset(testCase.s)
+
+			assert.ErrorIs(t, err, testCase.err)
+			if testCase.err != nil {
+				assert.Nil(t, option)
+				return
+			}
+
+			var settings settings
+			option(&settings)
+			assert.Equal(t, testCase.settings, settings)
+		})
+	}
+}
+
+func Test_formatTimestamp(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2022, time.March, 28, 10, 0, 0, 0, time.UTC)
+	timestamp := time.Date(2022, time.March, 28, 10, 3, 29, 123456789, time.UTC)
+
+	testCases := map[string]struct {
+		mode   TimestampMode
+		layout string
+		s      string
+	}{
+		"layout": {
+			mode:   TimestampLayout,
+			layout: time.RFC3339Nano,
+			s:      "2022-03-28T10:03:29.123456789Z",
+		},
+		"empty layout": {
+			mode: TimestampLayout,
+		},
+		"unix": {
+			mode: TimestampUnix,
+			s:    "1648461809",
+		},
+		"unix milliseconds": {
+			mode: TimestampUnixMilli,
+			s:    "1648461809123",
+		},
+		"unix microseconds": {
+			mode: TimestampUnixMicro,
+			s:    "1648461809123456",
+		},
+		"unix nanoseconds": {
+			mode: TimestampUnixNano,
+			s:    "1648461809123456789",
+		},
+		"elapsed": {
+			mode: TimestampElapsed,
+			s:    "209.123457s",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := formatTimestamp(testCase.mode, testCase.layout, timestamp, start)
+
+			assert.Equal(t, testCase.s, s)
+		})
+	}
+}
+
+func Test_Logger_timestampMode(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+	clock := func() time.Time {
+		return time.Date(2022, time.March, 28, 10, 3, 29, 0, time.UTC)
+	}
+
+	option, err := ParseTimestampPreset("unixms")
+	require.NoError(t, err)
+	logger := New(SetWriters(buffer), SetClock(clock), option)
+	logger.Info("parent")
+	child := logger.New(SetTimestampMode(TimestampUnix))
+	child.Info("child")
+
+	const expected = "1648461809000 INFO parent\n" +
+		"1648461809 INFO child\n"
+	assert.Equal(t, expected, buffer.String())
+}
+
+func Test_Logger_elapsedClock(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+	start := time.Date(2022, time.March, 28, 10, 3, 29, 0, time.UTC)
+	now := start
+	clock := func() time.Time {
+		return now
+	}
+
+	logger := New(SetWriters(buffer), SetClock(clock),
+		SetTimestampMode(TimestampElapsed))
+	now = now.Add(1500 * time.Millisecond)
+	logger.Info("parent")
+	child := logger.New(SetComponent("child"))
+	now = now.Add(time.Second)
+	child.Info("child")
+
+	const expected = "1.500000s INFO parent\n" +
+		"2.500000s INFO [child] child\n"
+	assert.Equal(t, expected, buffer.String())
+}
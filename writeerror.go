@@ -0,0 +1,123 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/qdm12/log/internal/writersreg"
+)
+
+// WriteErrorHandler is a function called when writing a line
+// to a writer fails. It receives the writer, the write error
+// and the line which could not be written.
+type WriteErrorHandler func(writer io.Writer, err error, line string)
+
+// FallbackToStderr is a write error handler writing the line
+// which could not be written to os.Stderr, unless the failing
+// writer is os.Stderr itself.
+func FallbackToStderr(writer io.Writer, err error, line string) {
+	stderrFallback(writer, err, line)
+}
+
+var stderrFallback = newFallbackHandler(writersRegistry, os.Stderr) //nolint:gochecknoglobals
+
+// newFallbackHandler returns a write error handler writing the
+// line which could not be written to the fallback writer given.
+// The fallback writer is registered once in the writers registry
+// given, on the first failed write, to serialize writes with the
+// loggers using it.
+func newFallbackHandler(registry *writersreg.Registry,
+	fallback io.Writer) WriteErrorHandler {
+	var once sync.Once
+	var mutex *sync.Mutex
+	return func(writer io.Writer, _ error, line string) {
+		if writer == fallback {
+			return
+		}
+
+		once.Do(func() {
+			mutex = registry.RegisterWriters([]io.Writer{fallback})[0]
+		})
+		if mutex != nil {
+			mutex.Lock()
+			defer mutex.Unlock()
+		}
+		_, _ = io.WriteString(fallback, line)
+	}
+}
+
+// ErrWriterDisabled is the error given to the write error handler
+// when a line is not written to a writer disabled after repeated
+// write failures.
+var ErrWriterDisabled = errors.New("writer is disabled after repeated failures")
+
+// writerBreaker disables writers after repeated failures,
+// and retries them periodically. It is shared between a
+// logger and its children.
+type writerBreaker struct {
+	maxFailures uint
+	retryPeriod time.Duration
+	mutex       sync.Mutex
+	// writerToState maps writer addresses to their state.
+	writerToState map[string]*writerState
+}
+
+type writerState struct {
+	failures uint
+	// retryAt is the time at which the writer is
+	// retried, and is zero if the writer is enabled.
+	retryAt time.Time
+}
+
+func newWriterBreaker(maxFailures uint, retryPeriod time.Duration) *writerBreaker {
+	return &writerBreaker{
+		maxFailures:   maxFailures,
+		retryPeriod:   retryPeriod,
+		writerToState: make(map[string]*writerState),
+	}
+}
+
+// allow returns true if the writer can be written to.
+// A disabled writer is allowed once its retry time is reached.
+func (b *writerBreaker) allow(writer io.Writer, now time.Time) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	state, ok := b.writerToState[writerAddress(writer)]
+	if !ok || state.retryAt.IsZero() {
+		return true
+	}
+	return !now.Before(state.retryAt)
+}
+
+// report records the result of a write to the writer, and
+// disables the writer if it failed too many times in a row.
+func (b *writerBreaker) report(writer io.Writer, err error, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	address := writerAddress(writer)
+	if err == nil {
+		delete(b.writerToState, address)
+		return
+	}
+
+	state, ok := b.writerToState[address]
+	if !ok {
+		state = new(writerState)
+		b.writerToState[address] = state
+	}
+
+	state.failures++
+	if state.failures >= b.maxFailures {
+		state.retryAt = now.Add(b.retryPeriod)
+	}
+}
+
+func writerAddress(writer io.Writer) string {
+	return fmt.Sprintf("%p", writer)
+}
@@ -0,0 +1,161 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/qdm12/log/internal/writersreg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testToggleWriter fails writing while failing is true.
+type testToggleWriter struct {
+	failing bool
+	lines   []string
+}
+
+var errTestWrite = errors.New("test write error")
+
+func (w *testToggleWriter) Write(p []byte) (n int, err error) {
+	if w.failing {
+		return 0, errTestWrite
+	}
+	w.lines = append(w.lines, string(p))
+	return len(p), nil
+}
+
+type testWriteError struct {
+	writer io.Writer
+	err    error
+	line   string
+}
+
+func Test_Logger_OnWriteError(t *testing.T) {
+	t.Parallel()
+
+	var writeErrors []testWriteError
+	handler := func(writer io.Writer, err error, line string) {
+		writeErrors = append(writeErrors, testWriteError{writer: writer, err: err, line: line})
+	}
+
+	writer := &testToggleWriter{failing: true}
+	parent := New(SetWriters(writer), SetTimeFormat(""), OnWriteError(handler))
+	child := parent.New(SetComponent("child"))
+
+	parent.Info("parent")
+	child.Info("child")
+	writer.failing = false
+	child.Info("success")
+
+	expected := []testWriteError{
+		{writer: writer, err: errTestWrite, line: "INFO parent\n"},
+		{writer: writer, err: errTestWrite, line: "INFO [child] child\n"},
+	}
+	assert.Equal(t, expected, writeErrors)
+	assert.Equal(t, []string{"INFO [child] success\n"}, writer.lines)
+}
+
+func Test_Logger_DisableFailingWriters(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	var writeErrors []error
+	handler := func(writer io.Writer, err error, line string) {
+		writeErrors = append(writeErrors, err)
+	}
+
+	writer := &testToggleWriter{failing: true}
+	logger := New(SetWriters(writer), SetTimeFormat(""), SetClock(clock),
+		OnWriteError(handler), DisableFailingWriters(2, time.Minute))
+
+	logger.Info("1") // first failure
+	logger.Info("2") // second failure, writer disabled
+	writer.failing = false
+	logger.Info("3") // writer disabled
+	now = now.Add(time.Minute)
+	writer.failing = true
+	logger.Info("4") // retry fails, writer disabled again
+	logger.Info("5") // writer disabled
+	now = now.Add(time.Minute)
+	writer.failing = false
+	logger.Info("6") // retry succeeds
+	writer.failing = true
+	logger.Info("7") // first failure again
+	writer.failing = false
+	logger.Info("8")
+
+	expectedErrors := []error{errTestWrite, errTestWrite, ErrWriterDisabled,
+		errTestWrite, ErrWriterDisabled, errTestWrite}
+	require.Equal(t, len(expectedErrors), len(writeErrors))
+	for i, err := range writeErrors {
+		assert.ErrorIs(t, err, expectedErrors[i])
+	}
+	assert.Equal(t, []string{"INFO 6\n", "INFO 8\n"}, writer.lines)
+	assert.Equal(t, uint64(6), logger.Stats().WriteErrors[writerName(writer)])
+}
+
+func Test_FallbackToStderr(t *testing.T) {
+	t.Parallel()
+
+	// Writing to os.Stderr failing must not loop writing to it.
+	FallbackToStderr(os.Stderr, errTestWrite, "")
+}
+
+func Test_newFallbackHandler(t *testing.T) {
+	t.Parallel()
+
+	registry := writersreg.NewRegistry()
+	fallback := &testToggleWriter{}
+	fallbackHandler := newFallbackHandler(registry, fallback)
+	var handledErrors []error
+	handler := func(writer io.Writer, err error, line string) {
+		handledErrors = append(handledErrors, err)
+		fallbackHandler(writer, err, line)
+	}
+
+	writer := &testToggleWriter{failing: true}
+	logger := New(SetWriters(writer), SetTimeFormat(""), OnWriteError(handler))
+	t.Cleanup(func() { _ = logger.Close() })
+	logger.Info("first")
+	logger.Info("second")
+
+	assert.Equal(t, []error{errTestWrite, errTestWrite}, handledErrors)
+	assert.Equal(t, []string{"INFO first\n", "INFO second\n"}, fallback.lines)
+
+	// The handler holds a single registry reference to the
+	// fallback writer, whatever the number of failed writes.
+	mutex := registry.RegisterWriters([]io.Writer{fallback})[0]
+	registry.ReleaseWriters([]io.Writer{fallback, fallback})
+	assert.NotSame(t, mutex, registry.RegisterWriters([]io.Writer{fallback})[0])
+}
+
+func Test_Logger_OnWriteError_patch(t *testing.T) {
+	t.Parallel()
+
+	writer := &testToggleWriter{failing: true}
+	buffer := bytes.NewBuffer(nil)
+	var logger *Logger
+	// the handler removes the failing writer from the logger,
+	// which must not deadlock.
+	handler := func(failingWriter io.Writer, err error, line string) {
+		logger.Patch(RemoveWriters(failingWriter))
+	}
+	logger = New(SetWriters(writer, buffer), SetTimeFormat(""),
+		OnWriteError(handler), SetDeduplication(time.Hour))
+	t.Cleanup(func() { _ = logger.Close() })
+
+	logger.Info("first")
+	logger.Info("first")
+	// the summary is written to the writers of the first record
+	logger.Info("second")
+
+	assert.Equal(t, []io.Writer{buffer}, logger.Writers())
+	assert.Equal(t, "INFO first\nINFO last message repeated 1 times\nINFO second\n", buffer.String())
+}
@@ -0,0 +1,37 @@
+package log
+
+import "io"
+
+// Writers returns the current writers of the logger.
+func (l *Logger) Writers() (writers []io.Writer) {
+	if l.members != nil {
+		return l.membersWriters()
+	}
+
+	l.settingsMutex.RLock()
+	defer l.settingsMutex.RUnlock()
+	writers = make([]io.Writer, len(l.settings.writers))
+	copy(writers, l.settings.writers)
+	return writers
+}
+
+// removeWriters returns a new slice of the writers given
+// without the writers to remove.
+func removeWriters(writers, toRemove []io.Writer) (filtered []io.Writer) {
+	filtered = make([]io.Writer, 0, len(writers))
+	for _, writer := range writers {
+		if !containsWriter(toRemove, writer) {
+			filtered = append(filtered, writer)
+		}
+	}
+	return filtered
+}
+
+func containsWriter(writers []io.Writer, writer io.Writer) bool {
+	for _, element := range writers {
+		if element == writer {
+			return true
+		}
+	}
+	return false
+}
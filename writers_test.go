@@ -0,0 +1,97 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Logger_Writers(t *testing.T) {
+	t.Parallel()
+
+	bufferA := bytes.NewBuffer(nil)
+	bufferB := bytes.NewBuffer(nil)
+	logger := New(SetWriters(bufferA, bufferB))
+
+	writers := logger.Writers()
+	assert.Equal(t, []io.Writer{bufferA, bufferB}, writers)
+
+	// Modifying the returned slice does not modify the logger.
+	writers[0] = io.Discard
+	assert.Equal(t, []io.Writer{bufferA, bufferB}, logger.Writers())
+}
+
+func Test_Logger_RemoveWriters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("patch", func(t *testing.T) {
+		t.Parallel()
+
+		main := bytes.NewBuffer(nil)
+		debug := bytes.NewBuffer(nil)
+		logger := New(SetWriters(main), SetTimeFormat(""))
+
+		logger.Patch(AddWriters(debug))
+		logger.Info("a")
+		logger.Patch(RemoveWriters(debug, io.Discard))
+		logger.Info("b")
+
+		assert.Equal(t, []io.Writer{main}, logger.Writers())
+		require.Len(t, logger.writersMutexes, 1)
+		assert.Empty(t, logger.settings.removedWriters)
+		assert.Equal(t, "INFO a\nINFO b\n", main.String())
+		assert.Equal(t, "INFO a\n", debug.String())
+	})
+
+	t.Run("child", func(t *testing.T) {
+		t.Parallel()
+
+		bufferA := bytes.NewBuffer(nil)
+		bufferB := bytes.NewBuffer(nil)
+		parent := New(SetWriters(bufferA, bufferB), SetTimeFormat(""))
+		child := parent.New(RemoveWriters(bufferA))
+
+		child.Info("child")
+		parent.Info("parent")
+
+		assert.Equal(t, []io.Writer{bufferB}, child.Writers())
+		require.Len(t, child.writersMutexes, 1)
+		assert.Equal(t, "INFO parent\n", bufferA.String())
+		assert.Equal(t, "INFO child\nINFO parent\n", bufferB.String())
+	})
+
+	t.Run("options order", func(t *testing.T) {
+		t.Parallel()
+
+		bufferA := bytes.NewBuffer(nil)
+		bufferB := bytes.NewBuffer(nil)
+		parent := New(SetWriters(bufferA, bufferB))
+
+		child := parent.New(RemoveWriters(bufferB), SetWriters(bufferA, bufferB))
+		assert.Equal(t, []io.Writer{bufferA, bufferB}, child.Writers())
+
+		child = parent.New(RemoveWriters(bufferB), AddWriters(bufferB))
+		assert.Equal(t, []io.Writer{bufferB}, child.Writers())
+
+		child = parent.New(SetWriters(bufferA, bufferB), RemoveWriters(bufferB))
+		assert.Equal(t, []io.Writer{bufferA}, child.Writers())
+	})
+}
+
+func Test_removeWriters(t *testing.T) {
+	t.Parallel()
+
+	bufferA := bytes.NewBuffer(nil)
+	bufferB := bytes.NewBuffer(nil)
+	bufferC := bytes.NewBuffer(nil)
+
+	filtered := removeWriters([]io.Writer{bufferA, bufferB, bufferC},
+		[]io.Writer{bufferB, io.Discard})
+
+	require.Len(t, filtered, 2)
+	assert.Same(t, bufferA, filtered[0])
+	assert.Same(t, bufferC, filtered[1])
+}